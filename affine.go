@@ -0,0 +1,90 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import "filippo.io/edwards25519/field"
+
+// An AffinePoint is a point stored in normalized form, with Z = 1. Adding an
+// AffinePoint to a Point with AddMixed or SubMixed is cheaper than Add or
+// Subtract, but converting a Point to an AffinePoint requires a field
+// inversion, so it's best suited for precomputed tables. Use SetAffinePoints
+// to amortize the inversion across many points.
+//
+// The zero value is NOT valid, and it may be used only as a receiver.
+type AffinePoint struct {
+	_ incomparable
+
+	// The point is internally represented as (y+x, y-x, 2dxy).
+	c affineCached
+}
+
+func checkAffineInitialized(points ...*AffinePoint) {
+	for _, p := range points {
+		if p.c.YplusX == (field.Element{}) && p.c.YminusX == (field.Element{}) {
+			panic("edwards25519: use of uninitialized AffinePoint")
+		}
+	}
+}
+
+// Set sets a = p, and returns a.
+func (a *AffinePoint) Set(p *Point) *AffinePoint {
+	checkInitialized(p)
+	a.c.FromP3(p)
+	return a
+}
+
+// SetAffinePoints sets out[i] = points[i] for every i, using a single field
+// inversion. The lengths of out and points must match.
+func SetAffinePoints(out []AffinePoint, points []*Point) {
+	if len(out) != len(points) {
+		panic("edwards25519: called SetAffinePoints with different size inputs")
+	}
+	checkInitialized(points...)
+
+	zs := make([]field.Element, 2*len(points))
+	zInvs, scratch := zs[:len(points)], zs[len(points):]
+	for i, p := range points {
+		zInvs[i].Set(&p.z)
+	}
+	invertAll(zInvs, zInvs, scratch)
+
+	for i, p := range points {
+		out[i].c.fromP3ZInv(p, &zInvs[i])
+	}
+}
+
+// SetAffinePoint sets v = a, and returns v.
+func (v *Point) SetAffinePoint(a *AffinePoint) *Point {
+	checkAffineInitialized(a)
+	// x = ((y+x) - (y-x)) / 2, y = ((y+x) + (y-x)) / 2
+	v.x.Subtract(&a.c.YplusX, &a.c.YminusX)
+	v.x.Multiply(&v.x, feHalf)
+	v.y.Add(&a.c.YplusX, &a.c.YminusX)
+	v.y.Multiply(&v.y, feHalf)
+	v.z.One()
+	v.t.Multiply(&v.x, &v.y)
+	return v
+}
+
+// feHalf is 1/2 mod p.
+var feHalf = new(field.Element).Invert(new(field.Element).Add(feOne, feOne))
+
+// AddMixed sets v = p + q, and returns v.
+func (v *Point) AddMixed(p *Point, q *AffinePoint) *Point {
+	checkInitialized(p)
+	checkAffineInitialized(q)
+	var result projP1xP1
+	result.AddAffine(p, &q.c)
+	return v.fromP1xP1(&result)
+}
+
+// SubMixed sets v = p - q, and returns v.
+func (v *Point) SubMixed(p *Point, q *AffinePoint) *Point {
+	checkInitialized(p)
+	checkAffineInitialized(q)
+	var result projP1xP1
+	result.SubAffine(p, &q.c)
+	return v.fromP1xP1(&result)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestAffinePoint(t *testing.T) {
+	f := func(x, y Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		q := new(Point).ScalarBaseMult(&y)
+		var qa AffinePoint
+		qa.Set(q)
+
+		sum := new(Point).AddMixed(p, &qa)
+		diff := new(Point).SubMixed(p, &qa)
+		back := new(Point).SetAffinePoint(&qa)
+		checkOnCurve(t, sum, diff, back)
+
+		return sum.Equal(new(Point).Add(p, q)) == 1 &&
+			diff.Equal(new(Point).Subtract(p, q)) == 1 &&
+			back.Equal(q) == 1 &&
+			p.AddMixed(p, &qa).Equal(sum) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSetAffinePoints(t *testing.T) {
+	points := []*Point{B, I, dalekScalarBasepoint, new(Point).Add(B, B)}
+	out := make([]AffinePoint, len(points))
+	SetAffinePoints(out, points)
+	for i, p := range points {
+		var want AffinePoint
+		want.Set(p)
+		if out[i].c.YplusX.Equal(&want.c.YplusX) != 1 ||
+			out[i].c.YminusX.Equal(&want.c.YminusX) != 1 ||
+			out[i].c.T2d.Equal(&want.c.T2d) != 1 {
+			t.Errorf("point %d: batch conversion does not match Set", i)
+		}
+	}
+
+	SetAffinePoints(nil, nil)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("uninitialized AffinePoint did not panic")
+		}
+	}()
+	new(Point).AddMixed(B, &AffinePoint{})
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_allocs
+
+package edwards25519
+
+// This file audits the heap allocations of the core operations, which are
+// expected to make none, thanks to the outlining of the functions that return
+// slices or pointers. It's behind a build tag because the results depend on
+// the compiler's inlining and escape analysis decisions, and on
+// instrumentation like the race detector. Run it with
+//
+//	go test -tags edwards25519_allocs -run Allocs
+//
+// after changes to the APIs or to their implementation.
+
+import "testing"
+
+var allocsSink byte
+
+func TestAllocsAudit(t *testing.T) {
+	enc := dalekScalarBasepoint.Bytes()
+	sc := dalekScalar.Bytes()
+	wide := make([]byte, 64)
+	copy(wide, sc)
+	scalarValues := [2]Scalar{*dalekScalar, *dalekScalar}
+	pointValues := [2]Point{*B, *dalekScalarBasepoint}
+	msmScalars, msmPoints := msmTestInputs(pippengerThreshold)
+	var scratch MSMScratch
+	new(Point).MultiScalarMultWithScratch(msmScalars, msmPoints, &scratch)
+	new(Point).VarTimeMultiScalarMultWithScratch(msmScalars, msmPoints, &scratch)
+	precomputed := NewPrecomputedPoint(B, 4)
+	precomputedEnc := make([]byte, 0, 2+96*8*64)
+	var msm MSM
+	fillMSM := func() {
+		msm.Reset()
+		for i := range msmScalars {
+			msm.Add(msmScalars[i], msmPoints[i])
+		}
+		msm.AddBase(dalekScalar)
+	}
+	fillMSM()
+	msm.Result(new(Point))
+	msm.VarTimeResult(new(Point))
+
+	tests := []struct {
+		name string
+		f    func()
+	}{
+		{"Point.SetBytes", func() {
+			p, _ := new(Point).SetBytes(enc)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.Bytes", func() {
+			allocsSink ^= dalekScalarBasepoint.Bytes()[0]
+		}},
+		{"Point.BytesMontgomery", func() {
+			allocsSink ^= dalekScalarBasepoint.BytesMontgomery()[0]
+		}},
+		{"Point.Add", func() {
+			p := new(Point).Add(B, dalekScalarBasepoint)
+			p.Subtract(p, B)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.ScalarMult", func() {
+			p := new(Point).ScalarMult(dalekScalar, dalekScalarBasepoint)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.ScalarBaseMult", func() {
+			p := new(Point).ScalarBaseMult(dalekScalar)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.VarTimeDoubleScalarBaseMult", func() {
+			p := new(Point).VarTimeDoubleScalarBaseMult(dalekScalar, dalekScalarBasepoint, dalekScalar)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.MultiScalarMult", func() {
+			p := new(Point).MultiScalarMult([]*Scalar{dalekScalar, dalekScalar},
+				[]*Point{B, dalekScalarBasepoint})
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.VarTimeMultiScalarMult", func() {
+			p := new(Point).VarTimeMultiScalarMult([]*Scalar{dalekScalar, dalekScalar},
+				[]*Point{B, dalekScalarBasepoint})
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.MultiScalarMultValues", func() {
+			p := new(Point).MultiScalarMultValues(scalarValues[:], pointValues[:])
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.VarTimeMultiScalarMultValues", func() {
+			p := new(Point).VarTimeMultiScalarMultValues(scalarValues[:], pointValues[:])
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.MultiScalarMultWithScratch", func() {
+			p := new(Point).MultiScalarMultWithScratch(msmScalars[:20], msmPoints[:20], &scratch)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"Point.VarTimeMultiScalarMultWithScratch", func() {
+			p := new(Point).VarTimeMultiScalarMultWithScratch(msmScalars, msmPoints, &scratch)
+			allocsSink ^= p.Bytes()[0]
+		}},
+		{"MSM.Result", func() {
+			fillMSM()
+			allocsSink ^= msm.Result(new(Point)).Bytes()[0]
+		}},
+		{"MSM.VarTimeResult", func() {
+			fillMSM()
+			allocsSink ^= msm.VarTimeResult(new(Point)).Bytes()[0]
+		}},
+		{"PrecomputedPoint.AppendBinary", func() {
+			enc, _ := precomputed.AppendBinary(precomputedEnc)
+			allocsSink ^= enc[len(enc)-1]
+		}},
+		{"Scalar.SetCanonicalBytes", func() {
+			s, _ := new(Scalar).SetCanonicalBytes(sc)
+			allocsSink ^= s.Bytes()[0]
+		}},
+		{"Scalar.SetUniformBytes", func() {
+			s, _ := new(Scalar).SetUniformBytes(wide)
+			allocsSink ^= s.Bytes()[0]
+		}},
+		{"Scalar.BytesBE", func() {
+			s, _ := new(Scalar).SetBytesBE(sc, ReductionModOrder)
+			allocsSink ^= s.BytesBE()[0]
+		}},
+		{"Scalar.Multiply", func() {
+			s := new(Scalar).MultiplyAdd(dalekScalar, dalekScalar, dalekScalar)
+			s.Invert(s)
+			allocsSink ^= s.Bytes()[0]
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tt.f); allocs > 0 {
+				t.Errorf("expected zero allocations, got %0.1v", allocs)
+			}
+		})
+	}
+}
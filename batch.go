@@ -0,0 +1,289 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"sync"
+
+	"filippo.io/edwards25519/field"
+)
+
+// A Batch is a list of group operations that are executed together by Run.
+//
+// Batching amortizes work across operations: all encodings share a single
+// field inversion, scratch memory is reused across calls to Run, and
+// independent operations can be spread across multiple goroutines.
+//
+// Arithmetic operations may take as inputs the destinations of operations
+// queued earlier in the same Batch, and Run produces the same results as
+// executing them one by one in the order they were queued. Run tracks these
+// dependencies by pointer, and only executes concurrently operations that
+// don't read or write each other's destinations. Encodings are performed after
+// all arithmetic operations have completed, so they see the final value of
+// their point.
+//
+// The zero value is an empty Batch ready to use.
+type Batch struct {
+	ops     []batchOp
+	encodes []batchEncode
+
+	// Scratch space for Run, retained across calls.
+	//
+	// zs is used for the batched inversion of Z coordinates, sorted for the
+	// operations sorted by dependency level, and levels, lastWrite, and
+	// lastRead for computing the levels.
+	zs        []field.Element
+	sorted    []batchOp
+	levels    []int
+	lastWrite map[*Point]int
+	lastRead  map[*Point]int
+}
+
+type batchOpKind int
+
+const (
+	batchAdd batchOpKind = iota
+	batchSubtract
+	batchScalarMult
+	batchScalarBaseMult
+)
+
+type batchOp struct {
+	kind    batchOpKind
+	v, p, q *Point
+	x       *Scalar
+}
+
+type batchEncode struct {
+	out *[32]byte
+	p   *Point
+}
+
+// Add queues v = p + q.
+func (b *Batch) Add(v, p, q *Point) {
+	b.ops = append(b.ops, batchOp{kind: batchAdd, v: v, p: p, q: q})
+}
+
+// Subtract queues v = p - q.
+func (b *Batch) Subtract(v, p, q *Point) {
+	b.ops = append(b.ops, batchOp{kind: batchSubtract, v: v, p: p, q: q})
+}
+
+// ScalarMult queues v = x * q, computed in constant time.
+func (b *Batch) ScalarMult(v *Point, x *Scalar, q *Point) {
+	b.ops = append(b.ops, batchOp{kind: batchScalarMult, v: v, x: x, q: q})
+}
+
+// ScalarBaseMult queues v = x * B, where B is the canonical generator,
+// computed in constant time.
+func (b *Batch) ScalarBaseMult(v *Point, x *Scalar) {
+	b.ops = append(b.ops, batchOp{kind: batchScalarBaseMult, v: v, x: x})
+}
+
+// Encode queues writing the canonical 32-byte encoding of p to out, as
+// returned by p.Bytes().
+func (b *Batch) Encode(out *[32]byte, p *Point) {
+	b.encodes = append(b.encodes, batchEncode{out: out, p: p})
+}
+
+// Len returns the number of queued operations, including encodings.
+func (b *Batch) Len() int {
+	return len(b.ops) + len(b.encodes)
+}
+
+// Reset removes all queued operations, retaining the allocated memory for
+// reuse by future operations.
+func (b *Batch) Reset() {
+	for i := range b.ops {
+		b.ops[i] = batchOp{}
+	}
+	for i := range b.encodes {
+		b.encodes[i] = batchEncode{}
+	}
+	for i := range b.sorted {
+		b.sorted[i] = batchOp{}
+	}
+	b.ops = b.ops[:0]
+	b.encodes = b.encodes[:0]
+}
+
+// Run executes all queued operations, and then resets the Batch.
+//
+// If workers is greater than one, arithmetic operations that don't depend on
+// each other are split across up to that many goroutines. Otherwise, they are
+// all executed in order by the calling goroutine.
+func (b *Batch) Run(workers int) {
+	if workers <= 1 {
+		runBatchOps(b.ops)
+	} else {
+		for _, ops := range b.sortByLevel() {
+			runBatchOpsParallel(ops, workers)
+		}
+	}
+
+	if len(b.encodes) > 0 {
+		n := len(b.encodes)
+		if cap(b.zs) < 2*n {
+			b.zs = make([]field.Element, 2*n)
+		}
+		zs, scratch := b.zs[:n], b.zs[n:2*n]
+		for i, e := range b.encodes {
+			checkInitialized(e.p)
+			zs[i].Set(&e.p.z)
+		}
+		invertAll(zs, zs, scratch)
+		for i, e := range b.encodes {
+			e.p.bytesWithZInv(e.out, &zs[i])
+		}
+	}
+
+	b.Reset()
+}
+
+// sortByLevel sorts the queued operations by dependency level, and returns
+// them grouped by level. Operations in the same level can run concurrently,
+// and each level must complete before the next one starts.
+func (b *Batch) sortByLevel() [][]batchOp {
+	if b.lastWrite == nil {
+		b.lastWrite = make(map[*Point]int)
+		b.lastRead = make(map[*Point]int)
+	}
+	if cap(b.levels) < len(b.ops) {
+		b.levels = make([]int, len(b.ops))
+		b.sorted = make([]batchOp, len(b.ops))
+	}
+	levels, sorted := b.levels[:len(b.ops)], b.sorted[:len(b.ops)]
+
+	// The level of an operation is one more than the highest level of any
+	// previous operation that writes one of its inputs, or that reads or
+	// writes its destination.
+	maxLevel := 0
+	for i, op := range b.ops {
+		level := b.lastWrite[op.v]
+		if l := b.lastRead[op.v]; l > level {
+			level = l
+		}
+		for _, in := range [...]*Point{op.p, op.q} {
+			if l := b.lastWrite[in]; l > level {
+				level = l
+			}
+		}
+		level++
+
+		b.lastWrite[op.v] = level
+		for _, in := range [...]*Point{op.p, op.q} {
+			if in != nil && b.lastRead[in] < level {
+				b.lastRead[in] = level
+			}
+		}
+		levels[i] = level
+		if level > maxLevel {
+			maxLevel = level
+		}
+	}
+	for p := range b.lastWrite {
+		delete(b.lastWrite, p)
+	}
+	for p := range b.lastRead {
+		delete(b.lastRead, p)
+	}
+
+	// Counting sort the operations by level, keeping their relative order.
+	starts := make([]int, maxLevel+2)
+	for _, l := range levels {
+		starts[l+1]++
+	}
+	for l := 1; l < len(starts); l++ {
+		starts[l] += starts[l-1]
+	}
+	groups := make([][]batchOp, maxLevel)
+	for l := range groups {
+		groups[l] = sorted[starts[l+1]:starts[l+1]:starts[l+2]]
+	}
+	for i, op := range b.ops {
+		groups[levels[i]-1] = append(groups[levels[i]-1], op)
+	}
+	return groups
+}
+
+// runBatchOpsParallel executes ops, which must not depend on each other,
+// across up to workers goroutines.
+func runBatchOpsParallel(ops []batchOp, workers int) {
+	if workers > len(ops) {
+		workers = len(ops)
+	}
+	if workers <= 1 {
+		runBatchOps(ops)
+		return
+	}
+	var wg sync.WaitGroup
+	chunk := (len(ops) + workers - 1) / workers
+	for i := 0; i < len(ops); i += chunk {
+		end := i + chunk
+		if end > len(ops) {
+			end = len(ops)
+		}
+		wg.Add(1)
+		go func(ops []batchOp) {
+			defer wg.Done()
+			runBatchOps(ops)
+		}(ops[i:end])
+	}
+	wg.Wait()
+}
+
+func runBatchOps(ops []batchOp) {
+	for _, op := range ops {
+		switch op.kind {
+		case batchAdd:
+			op.v.Add(op.p, op.q)
+		case batchSubtract:
+			op.v.Subtract(op.p, op.q)
+		case batchScalarMult:
+			op.v.ScalarMult(op.x, op.q)
+		case batchScalarBaseMult:
+			op.v.ScalarBaseMult(op.x)
+		}
+	}
+}
+
+// bytesWithZInv works like bytes, but takes a precomputed 1 / Z.
+func (v *Point) bytesWithZInv(buf *[32]byte, zInv *field.Element) []byte {
+	var x, y field.Element
+	x.Multiply(&v.x, zInv) // x = X / Z
+	y.Multiply(&v.y, zInv) // y = Y / Z
+
+	out := copyFieldElement(buf, &y)
+	out[31] |= byte(x.IsNegative() << 7)
+	return out
+}
+
+// invertAll sets out[i] = 1 / in[i] for every i, using a single field inversion
+// (Montgomery's trick). out, in, and products must have the same length. out
+// and in may be the same slice, while products is used as scratch space. All
+// elements of in must be nonzero.
+func invertAll(out, in, products []field.Element) {
+	if len(out) != len(in) || len(products) != len(in) {
+		panic("edwards25519: internal error: invertAll called with different size inputs")
+	}
+	if len(in) == 0 {
+		return
+	}
+
+	// products[i] = in[0] * in[1] * ... * in[i]
+	products[0].Set(&in[0])
+	for i := 1; i < len(in); i++ {
+		products[i].Multiply(&products[i-1], &in[i])
+	}
+
+	var acc, tmp field.Element
+	acc.Invert(&products[len(in)-1]) // acc = 1 / (in[0] * ... * in[n-1])
+	for i := len(in) - 1; i > 0; i-- {
+		tmp.Set(&in[i])                       // save in[i] in case out aliases in
+		out[i].Multiply(&acc, &products[i-1]) // out[i] = 1 / in[i]
+		acc.Multiply(&acc, &tmp)              // acc = 1 / (in[0] * ... * in[i-1])
+	}
+	out[0].Set(&acc)
+}
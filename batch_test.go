@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"testing"
+	"testing/quick"
+
+	"filippo.io/edwards25519/field"
+)
+
+func TestBatch(t *testing.T) {
+	f := func(x, y, z Scalar, workers uint8) bool {
+		var p, q Point
+		p.ScalarBaseMult(&x)
+		q.ScalarBaseMult(&y)
+
+		var b Batch
+		var sum, diff, mul, base Point
+		var encSum, encP, encMul [32]byte
+		b.Add(&sum, &p, &q)
+		b.Subtract(&diff, &p, &q)
+		b.ScalarMult(&mul, &z, &q)
+		b.ScalarBaseMult(&base, &z)
+		b.Encode(&encSum, &sum)
+		b.Encode(&encP, &p)
+		b.Encode(&encMul, &mul)
+		if b.Len() != 7 {
+			return false
+		}
+		b.Run(int(workers % 8))
+		if b.Len() != 0 {
+			return false
+		}
+
+		var check Point
+		checkOnCurve(t, &sum, &diff, &mul, &base)
+		if sum.Equal(check.Add(&p, &q)) != 1 ||
+			diff.Equal(check.Subtract(&p, &q)) != 1 ||
+			mul.Equal(check.ScalarMult(&z, &q)) != 1 ||
+			base.Equal(check.ScalarBaseMult(&z)) != 1 {
+			return false
+		}
+		return bytes.Equal(encSum[:], sum.Bytes()) &&
+			bytes.Equal(encP[:], p.Bytes()) &&
+			bytes.Equal(encMul[:], mul.Bytes())
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestBatchDependencies(t *testing.T) {
+	f := func(x, y Scalar, workers uint8) bool {
+		// Compute (x + y) * B in a few different roundabout ways, chaining
+		// operations and overwriting points that earlier operations read.
+		var b Batch
+		var p, q, r, s, sum Point
+		var enc [32]byte
+		b.ScalarBaseMult(&p, &x)
+		b.ScalarBaseMult(&q, &y)
+		b.Add(&r, &p, &q)             // r = (x + y) * B
+		b.Subtract(&s, &r, &q)        // s = x * B
+		b.ScalarBaseMult(&q, &x)      // q = x * B, after the read above
+		b.Subtract(&p, &p, &q)        // p = 0
+		b.Add(&sum, &r, &p)           // sum = (x + y) * B
+		b.Add(&s, &s, &r)             // s = (2x + y) * B
+		b.Subtract(&s, &s, &q)        // s = (x + y) * B
+		b.ScalarMult(&r, scOne, &sum) // r = (x + y) * B
+		b.Encode(&enc, &s)
+		b.Run(int(workers % 8))
+
+		var check Point
+		check.ScalarBaseMult(new(Scalar).Add(&x, &y))
+		checkOnCurve(t, &p, &q, &r, &s, &sum)
+		return p.Equal(I) == 1 && q.Equal(new(Point).ScalarBaseMult(&x)) == 1 &&
+			r.Equal(&check) == 1 && s.Equal(&check) == 1 &&
+			sum.Equal(&check) == 1 && bytes.Equal(enc[:], check.Bytes())
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvertAll(t *testing.T) {
+	f := func(a, b, c Scalar) bool {
+		var in [3]field.Element
+		for i, s := range []*Scalar{&a, &b, &c} {
+			p := new(Point).ScalarBaseMult(s)
+			in[i].Set(&p.z)
+		}
+		want := make([]field.Element, len(in))
+		for i := range in {
+			want[i].Invert(&in[i])
+		}
+		invertAll(in[:], in[:], make([]field.Element, len(in)))
+		for i := range in {
+			if in[i].Equal(&want[i]) != 1 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
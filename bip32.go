@@ -0,0 +1,119 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import "errors"
+
+// This file implements the scalar arithmetic of BIP32-Ed25519, as specified in
+// "BIP32-Ed25519 Hierarchical Deterministic Keys over a Non-linear Keyspace" by
+// Khovratovich and Law. Extended private keys are 64 bytes, kL || kR, where kL
+// is a 256-bit little-endian integer that is used as a scalar, but which is
+// NOT reduced modulo l between derivations. Reducing or clamping it, as the
+// generic Scalar APIs do, produces keys incompatible with other wallets.
+//
+// The HMAC-SHA512 computations producing z are left to the caller.
+
+// BIP32Ed25519ChildLeft returns the left half of a child extended private key,
+// (kL + 8 * ZL) mod 2²⁵⁶, where kL is the 32-byte left half of the parent key,
+// and ZL is the first 28 bytes of z, the 64-byte HMAC-SHA512 output of the
+// derivation step. Both values are little-endian integers, and the addition is
+// not reduced modulo l.
+//
+// The specification requires discarding child keys for which the sum overflows
+// 256 bits, but like deployed implementations, BIP32Ed25519ChildLeft instead
+// discards the carry. Keys derived from a valid root key can only overflow
+// after more than 2²⁰ levels of derivation. When the carry is discarded, the
+// child public key is not the parent public key plus 8 * ZL * B.
+//
+// If the inputs are not of the right length, or if the result is a multiple of
+// l, in which case the specification requires discarding the child key,
+// BIP32Ed25519ChildLeft returns nil and an error.
+//
+// The computation is done in constant time, except for which error is
+// returned.
+func BIP32Ed25519ChildLeft(kL, z []byte) ([]byte, error) {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var out [32]byte
+	return bip32Ed25519ChildLeft(&out, kL, z)
+}
+
+func bip32Ed25519ChildLeft(out *[32]byte, kL, z []byte) ([]byte, error) {
+	if len(kL) != 32 {
+		return nil, errors.New("edwards25519: invalid BIP32-Ed25519 key length")
+	}
+	if len(z) != 64 {
+		return nil, errors.New("edwards25519: invalid BIP32-Ed25519 derivation output length")
+	}
+
+	// 8 * ZL fits in 28 * 8 + 3 = 227 bits.
+	var zl8 [32]byte
+	for i := 0; i < 28; i++ {
+		zl8[i] |= z[i] << 3
+		zl8[i+1] |= z[i] >> 5
+	}
+
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		sum := uint16(kL[i]) + uint16(zl8[i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+
+	var wide [64]byte
+	copy(wide[:], out[:])
+	if new(Scalar).SetWideBytes(&wide).Equal(NewScalar()) == 1 {
+		return nil, errors.New("edwards25519: BIP32-Ed25519 child key is a multiple of the group order")
+	}
+
+	return out[:], nil
+}
+
+// BIP32Ed25519ChildRight returns the right half of a child extended private
+// key, (kR + ZR) mod 2²⁵⁶, where kR is the 32-byte right half of the parent
+// key, and ZR is the last 32 bytes of z, the 64-byte HMAC-SHA512 output of the
+// derivation step. Both values are little-endian integers.
+//
+// If the inputs are not of the right length, BIP32Ed25519ChildRight returns
+// nil and an error. The computation is done in constant time.
+func BIP32Ed25519ChildRight(kR, z []byte) ([]byte, error) {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var out [32]byte
+	return bip32Ed25519ChildRight(&out, kR, z)
+}
+
+func bip32Ed25519ChildRight(out *[32]byte, kR, z []byte) ([]byte, error) {
+	if len(kR) != 32 {
+		return nil, errors.New("edwards25519: invalid BIP32-Ed25519 key length")
+	}
+	if len(z) != 64 {
+		return nil, errors.New("edwards25519: invalid BIP32-Ed25519 derivation output length")
+	}
+
+	var carry uint16
+	for i := 0; i < 32; i++ {
+		sum := uint16(kR[i]) + uint16(z[32+i]) + carry
+		out[i] = byte(sum)
+		carry = sum >> 8
+	}
+	return out[:], nil
+}
+
+// SetBIP32Ed25519Bytes sets s = kL mod l, where kL is the 32-byte little-endian
+// left half of a BIP32-Ed25519 extended private key, and returns s. If kL is
+// not of the right length, SetBIP32Ed25519Bytes returns nil and an error, and
+// the receiver is unchanged.
+//
+// Unlike SetBytesWithClamping, SetBIP32Ed25519Bytes does not clamp kL, which
+// would change the value of derived keys. The public key is then s * B.
+func (s *Scalar) SetBIP32Ed25519Bytes(kL []byte) (*Scalar, error) {
+	if len(kL) != 32 {
+		return nil, errors.New("edwards25519: invalid BIP32-Ed25519 key length")
+	}
+	var wide [64]byte
+	copy(wide[:], kL)
+	return s.SetWideBytes(&wide), nil
+}
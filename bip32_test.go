@@ -0,0 +1,176 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func TestBIP32Ed25519ChildLeft(t *testing.T) {
+	f := func(kL [32]byte, z [64]byte) bool {
+		// Like root keys, clear the top bits to avoid overflow.
+		kL[31] &= 0x1f
+
+		got, err := BIP32Ed25519ChildLeft(kL[:], z[:])
+		if err != nil {
+			return false
+		}
+
+		zl8 := new(big.Int).Lsh(bigIntFromLittleEndianBytes(z[:28]), 3)
+		want := bigIntFromLittleEndianBytes(kL[:])
+		want.Add(want, zl8)
+		if bigIntFromLittleEndianBytes(got).Cmp(want) != 0 {
+			return false
+		}
+
+		// The public key of the child is the public key of the parent plus
+		// 8 * ZL * B, which allows deriving child public keys.
+		parent, _ := new(Scalar).SetBIP32Ed25519Bytes(kL[:])
+		child, _ := new(Scalar).SetBIP32Ed25519Bytes(got)
+		var tweakBytes [64]byte
+		copy(tweakBytes[:], swapEndianness(zl8.FillBytes(make([]byte, 32))))
+		tweak := new(Scalar).SetWideBytes(&tweakBytes)
+
+		A := new(Point).ScalarBaseMult(parent)
+		A.Add(A, new(Point).ScalarBaseMult(tweak))
+		return A.Equal(new(Point).ScalarBaseMult(child)) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	// Like deployed implementations, the carry out of 256 bits is discarded:
+	// (2²⁵⁶ - 1) + 8 * 1 = 7 mod 2²⁵⁶.
+	var z [64]byte
+	z[0] = 1
+	kL := bytes.Repeat([]byte{0xff}, 32)
+	want := append([]byte{7}, make([]byte, 31)...)
+	if got, err := BIP32Ed25519ChildLeft(kL, z[:]); err != nil {
+		t.Errorf("unexpected error on overflow: %v", err)
+	} else if !bytes.Equal(got, want) {
+		t.Errorf("overflow: got %x, expected %x", got, want)
+	}
+	if _, err := BIP32Ed25519ChildLeft(kL[:31], z[:]); err == nil {
+		t.Error("expected error on short key")
+	}
+	if _, err := BIP32Ed25519ChildLeft(kL, z[:32]); err == nil {
+		t.Error("expected error on short z")
+	}
+
+	// l - 8 + 8 * 1 = l is a multiple of l, so the child must be discarded.
+	lMinusEight := bigIntFromLittleEndianBytes(scalarMinusOneBytes[:])
+	lMinusEight.Sub(lMinusEight, big.NewInt(7))
+	kL = swapEndianness(lMinusEight.FillBytes(make([]byte, 32)))
+	if _, err := BIP32Ed25519ChildLeft(kL, append([]byte{1}, make([]byte, 63)...)); err == nil {
+		t.Error("expected error on multiple of l")
+	}
+}
+
+func TestBIP32Ed25519Vectors(t *testing.T) {
+	// The D1 and D1_H0 vectors of the ed25519-bip32 Rust crate, which
+	// implements the derivation of Cardano wallets, from
+	// https://github.com/typed-io/rust-ed25519-bip32/blob/master/src/derivation/mod.rs.
+	// Each extended key is kL || kR || chain code, and D1_H0 is the hardened
+	// child of D1 with index 2^31.
+	parent := decodeHex("f8a29231ee38d6c5bf715d5bac21c750577aa3798b22d79d65bf97d6fadea15a" +
+		"dcd1ee1abdf78bd4be64731a12deb94d3671784112eb6f364b871851fd1c9a24" +
+		"7384db9ad6003bbd08b3b1ddc0d07a597293ff85e961bf252b331262eddfad0d")
+	child := decodeHex("60d399da83ef80d8d4f8d223239efdc2b8fef387e1b5219137ffb4e8fbdea15a" +
+		"dc9366b7d003af37c11396de9a83734e30e05e851efa32745c9cd7b42712c890" +
+		"608763770eddf77248ab652984b21b849760d1da74a6f5bd633ce41adceef07a")
+	kL, kR, chainCode := parent[:32], parent[32:64], parent[64:]
+
+	// Hardened derivation computes z = HMAC-SHA512(c, 0x00 || kL || kR || i)
+	// and the child chain code as the right half of
+	// HMAC-SHA512(c, 0x01 || kL || kR || i), with i in little-endian.
+	index := binary.LittleEndian.AppendUint32(nil, 1<<31)
+	h := hmac.New(sha512.New, chainCode)
+	h.Write([]byte{0x00})
+	h.Write(parent[:64])
+	h.Write(index)
+	z := h.Sum(nil)
+	h = hmac.New(sha512.New, chainCode)
+	h.Write([]byte{0x01})
+	h.Write(parent[:64])
+	h.Write(index)
+	childChainCode := h.Sum(nil)[32:]
+
+	childL, err := BIP32Ed25519ChildLeft(kL, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	childR, err := BIP32Ed25519ChildRight(kR, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(childL, child[:32]) {
+		t.Errorf("kL: got %x, expected %x", childL, child[:32])
+	}
+	if !bytes.Equal(childR, child[32:64]) {
+		t.Errorf("kR: got %x, expected %x", childR, child[32:64])
+	}
+	if !bytes.Equal(childChainCode, child[64:]) {
+		t.Errorf("chain code: got %x, expected %x", childChainCode, child[64:])
+	}
+
+	// The child public key can also be derived from the parent public key, as
+	// A + 8 * ZL * B.
+	parentScalar, _ := new(Scalar).SetBIP32Ed25519Bytes(kL)
+	childScalar, _ := new(Scalar).SetBIP32Ed25519Bytes(childL)
+	zl8, _ := BIP32Ed25519ChildLeft(make([]byte, 32), z)
+	tweak, _ := new(Scalar).SetBIP32Ed25519Bytes(zl8)
+	A := new(Point).ScalarBaseMult(parentScalar)
+	A.Add(A, new(Point).ScalarBaseMult(tweak))
+	if got := new(Point).ScalarBaseMult(childScalar); got.Equal(A) != 1 {
+		t.Errorf("child public key: got %x, expected %x", got.Bytes(), A.Bytes())
+	}
+}
+
+func TestBIP32Ed25519ChildRight(t *testing.T) {
+	f := func(kR [32]byte, z [64]byte) bool {
+		got, err := BIP32Ed25519ChildRight(kR[:], z[:])
+		if err != nil {
+			return false
+		}
+		want := bigIntFromLittleEndianBytes(kR[:])
+		want.Add(want, bigIntFromLittleEndianBytes(z[32:]))
+		want.Mod(want, new(big.Int).Lsh(big.NewInt(1), 256))
+		return bigIntFromLittleEndianBytes(got).Cmp(want) == 0
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := BIP32Ed25519ChildRight(make([]byte, 31), make([]byte, 64)); err == nil {
+		t.Error("expected error on short key")
+	}
+	if _, err := BIP32Ed25519ChildRight(make([]byte, 32), make([]byte, 63)); err == nil {
+		t.Error("expected error on short z")
+	}
+}
+
+func TestSetBIP32Ed25519Bytes(t *testing.T) {
+	// Unlike SetBytesWithClamping, bit 254 is not set and the low bits are
+	// preserved.
+	kL := make([]byte, 32)
+	kL[0] = 3
+	s, err := new(Scalar).SetBIP32Ed25519Bytes(kL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(s.Bytes(), kL) {
+		t.Errorf("got %x, expected %x", s.Bytes(), kL)
+	}
+
+	if _, err := new(Scalar).SetBIP32Ed25519Bytes(kL[:31]); err == nil {
+		t.Error("expected error on short key")
+	}
+}
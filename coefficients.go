@@ -0,0 +1,58 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+)
+
+// batchCoefficientsDomain separates the BatchCoefficients PRF from other
+// uses of SHA-512.
+const batchCoefficientsDomain = "filippo.io/edwards25519 batch coefficients v1"
+
+// BatchCoefficients expands seed into n deterministic 128-bit scalars, suitable
+// as the random coefficients of batch verification, and returns them.
+//
+// The coefficients are produced by the following PRF construction, so that
+// other implementations can reproduce them. For j = 0, 1, 2, ..., block j is
+//
+//	SHA-512(domain || uint64le(len(seed)) || seed || uint64le(j))
+//
+// where domain is the ASCII string "filippo.io/edwards25519 batch coefficients
+// v1". Coefficient i is the little-endian 128-bit integer encoded by bytes
+// 16 * (i mod 4) to 16 * (i mod 4) + 16 of block i / 4.
+//
+// The seed should be secret and unpredictable by whoever produced the
+// batch, for example the output of a hash of all the batch inputs and of
+// fresh randomness. Decoding is done in constant time.
+func BatchCoefficients(seed []byte, n int) []*Scalar {
+	if n < 0 {
+		panic("edwards25519: negative count passed to BatchCoefficients")
+	}
+
+	scalars := make([]Scalar, n)
+	out := make([]*Scalar, n)
+
+	h := sha512.New()
+	var lenSeed, counter [8]byte
+	binary.LittleEndian.PutUint64(lenSeed[:], uint64(len(seed)))
+	var block [sha512.Size]byte
+	for i := range scalars {
+		if i%4 == 0 {
+			h.Reset()
+			h.Write([]byte(batchCoefficientsDomain))
+			h.Write(lenSeed[:])
+			h.Write(seed)
+			binary.LittleEndian.PutUint64(counter[:], uint64(i/4))
+			h.Write(counter[:])
+			h.Sum(block[:0])
+		}
+		off := 16 * (i % 4)
+		scalars[i].setShortBytes(block[off : off+16])
+		out[i] = &scalars[i]
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"testing"
+)
+
+func TestBatchCoefficients(t *testing.T) {
+	seed := []byte("seed")
+	coeffs := BatchCoefficients(seed, 9)
+	if len(coeffs) != 9 {
+		t.Fatalf("got %d coefficients, expected 9", len(coeffs))
+	}
+
+	// Recompute the coefficients following the documented construction.
+	for i, c := range coeffs {
+		h := sha512.New()
+		h.Write([]byte("filippo.io/edwards25519 batch coefficients v1"))
+		binary.Write(h, binary.LittleEndian, uint64(len(seed)))
+		h.Write(seed)
+		binary.Write(h, binary.LittleEndian, uint64(i/4))
+		block := h.Sum(nil)
+		want := append(block[16*(i%4):16*(i%4)+16], make([]byte, 16)...)
+		if !bytes.Equal(c.Bytes(), want) {
+			t.Errorf("coefficient %d: got %x, expected %x", i, c.Bytes(), want)
+		}
+	}
+
+	// Coefficients are deterministic, and depend on the seed.
+	if BatchCoefficients(seed, 9)[8].Equal(coeffs[8]) != 1 {
+		t.Error("coefficients are not deterministic")
+	}
+	if BatchCoefficients([]byte("seee"), 1)[0].Equal(coeffs[0]) == 1 {
+		t.Error("coefficients do not depend on the seed")
+	}
+	// A longer request extends a shorter one.
+	if short := BatchCoefficients(seed, 5); short[4].Equal(coeffs[4]) != 1 {
+		t.Error("coefficients depend on n")
+	}
+
+	if len(BatchCoefficients(seed, 0)) != 0 {
+		t.Error("expected no coefficients for n = 0")
+	}
+}
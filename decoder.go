@@ -0,0 +1,51 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+// A PointDecoder reads and decodes a stream of concatenated 32-byte point
+// encodings, without buffering more than one encoding at a time.
+type PointDecoder struct {
+	r   io.Reader
+	buf [32]byte
+	n   int64
+}
+
+// NewPointDecoder returns a new PointDecoder that reads from r.
+func NewPointDecoder(r io.Reader) *PointDecoder {
+	return &PointDecoder{r: r}
+}
+
+// Decode reads the next 32-byte encoding from the stream and sets p to the
+// point it represents, following the same rules as Point.SetBytes.
+//
+// At the end of the stream, Decode returns io.EOF. If the stream ends in the
+// middle of an encoding, Decode returns io.ErrUnexpectedEOF. If the encoding
+// is not a valid point, Decode returns an error and p is unchanged, but the
+// encoding is consumed and the next call to Decode will read the following
+// one. Errors from the underlying reader are returned as-is.
+func (d *PointDecoder) Decode(p *Point) error {
+	n, err := io.ReadFull(d.r, d.buf[:])
+	d.n += int64(n)
+	if err != nil {
+		return err
+	}
+	if _, err := p.SetBytes(d.buf[:]); err != nil {
+		offset := strconv.FormatInt(d.n-int64(len(d.buf)), 10)
+		return errors.New("edwards25519: invalid point encoding at offset " + offset)
+	}
+	return nil
+}
+
+// InputOffset returns the number of bytes read from the underlying reader so
+// far.
+func (d *PointDecoder) InputOffset() int64 {
+	return d.n
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestPointDecoder(t *testing.T) {
+	invalid := decodeHex("efffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	var stream []byte
+	stream = append(stream, B.Bytes()...)
+	stream = append(stream, invalid...)
+	stream = append(stream, dalekScalarBasepoint.Bytes()...)
+	stream = append(stream, I.Bytes()[:10]...)
+
+	d := NewPointDecoder(bytes.NewReader(stream))
+	p := NewIdentityPoint()
+	if err := d.Decode(p); err != nil || p.Equal(B) != 1 {
+		t.Fatalf("first Decode: %v", err)
+	}
+	if err := d.Decode(p); err == nil {
+		t.Fatal("expected error for invalid encoding")
+	} else if p.Equal(B) != 1 {
+		t.Error("Decode modified the Point on an invalid encoding")
+	}
+	if err := d.Decode(p); err != nil || p.Equal(dalekScalarBasepoint) != 1 {
+		t.Fatalf("third Decode: %v", err)
+	}
+	if err := d.Decode(p); !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("expected io.ErrUnexpectedEOF, got %v", err)
+	}
+	if off := d.InputOffset(); off != int64(len(stream)) {
+		t.Errorf("InputOffset() = %d, want %d", off, len(stream))
+	}
+
+	d = NewPointDecoder(bytes.NewReader(B.Bytes()))
+	if err := d.Decode(p); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Decode(p); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 
 	"filippo.io/edwards25519/field"
+	"filippo.io/edwards25519/internal/formulas"
 )
 
 // Point types.
@@ -194,9 +195,7 @@ func copyFieldElement(buf *[32]byte, v *field.Element) []byte {
 // Conversions.
 
 func (v *projP2) FromP1xP1(p *projP1xP1) *projP2 {
-	v.X.Multiply(&p.X, &p.T)
-	v.Y.Multiply(&p.Y, &p.Z)
-	v.Z.Multiply(&p.Z, &p.T)
+	(*formulas.P2)(v).FromP1xP1((*formulas.P1xP1)(p))
 	return v
 }
 
@@ -208,53 +207,43 @@ func (v *projP2) FromP3(p *Point) *projP2 {
 }
 
 func (v *Point) fromP1xP1(p *projP1xP1) *Point {
-	v.x.Multiply(&p.X, &p.T)
-	v.y.Multiply(&p.Y, &p.Z)
-	v.z.Multiply(&p.Z, &p.T)
-	v.t.Multiply(&p.X, &p.Y)
+	formulas.P3FromP1xP1(&v.x, &v.y, &v.z, &v.t, (*formulas.P1xP1)(p))
 	return v
 }
 
 func (v *Point) fromP2(p *projP2) *Point {
-	v.x.Multiply(&p.X, &p.Z)
-	v.y.Multiply(&p.Y, &p.Z)
-	v.z.Square(&p.Z)
-	v.t.Multiply(&p.X, &p.Y)
+	formulas.P3FromP2(&v.x, &v.y, &v.z, &v.t, (*formulas.P2)(p))
 	return v
 }
 
-// d is a constant in the curve equation.
-var d, _ = new(field.Element).SetBytes([]byte{
-	0xa3, 0x78, 0x59, 0x13, 0xca, 0x4d, 0xeb, 0x75,
-	0xab, 0xd8, 0x41, 0x41, 0x4d, 0x0a, 0x70, 0x00,
-	0x98, 0xe8, 0x79, 0x77, 0x79, 0x40, 0xc7, 0x8c,
-	0x73, 0xfe, 0x6f, 0x2b, 0xee, 0x6c, 0x03, 0x52})
-var d2 = new(field.Element).Add(d, d)
+// d is a constant in the curve equation, and d2 is 2 * d.
+var d, d2 = formulas.D, formulas.D2
 
 func (v *projCached) FromP3(p *Point) *projCached {
-	v.YplusX.Add(&p.y, &p.x)
-	v.YminusX.Subtract(&p.y, &p.x)
-	v.Z.Set(&p.z)
-	v.T2d.Multiply(&p.t, d2)
+	(*formulas.Cached)(v).FromP3(&p.x, &p.y, &p.z, &p.t)
 	return v
 }
 
 func (v *affineCached) FromP3(p *Point) *affineCached {
-	v.YplusX.Add(&p.y, &p.x)
-	v.YminusX.Subtract(&p.y, &p.x)
-	v.T2d.Multiply(&p.t, d2)
-
 	var invZ field.Element
 	invZ.Invert(&p.z)
-	v.YplusX.Multiply(&v.YplusX, &invZ)
-	v.YminusX.Multiply(&v.YminusX, &invZ)
-	v.T2d.Multiply(&v.T2d, &invZ)
+	return v.fromP3ZInv(p, &invZ)
+}
+
+// fromP3ZInv works like FromP3, but takes the precomputed inverse of p.z, so
+// that the inversion can be shared across many points with invertAll.
+func (v *affineCached) fromP3ZInv(p *Point, invZ *field.Element) *affineCached {
+	(*formulas.AffineCached)(v).FromP3ZInv(&p.x, &p.y, &p.t, invZ)
 	return v
 }
 
 // (Re)addition and subtraction.
 
 // Add sets v = p + q, and returns v.
+//
+// The addition formulas are complete: they are correct for all inputs,
+// including the identity and p == q, without any special-case handling. There
+// is no faster path for inputs known to be in general position.
 func (v *Point) Add(p, q *Point) *Point {
 	checkInitialized(p, q)
 	qCached := new(projCached).FromP3(q)
@@ -271,100 +260,29 @@ func (v *Point) Subtract(p, q *Point) *Point {
 }
 
 func (v *projP1xP1) Add(p *Point, q *projCached) *projP1xP1 {
-	var YplusX, YminusX, PP, MM, TT2d, ZZ2 field.Element
-
-	YplusX.Add(&p.y, &p.x)
-	YminusX.Subtract(&p.y, &p.x)
-
-	PP.Multiply(&YplusX, &q.YplusX)
-	MM.Multiply(&YminusX, &q.YminusX)
-	TT2d.Multiply(&p.t, &q.T2d)
-	ZZ2.Multiply(&p.z, &q.Z)
-
-	ZZ2.Add(&ZZ2, &ZZ2)
-
-	v.X.Subtract(&PP, &MM)
-	v.Y.Add(&PP, &MM)
-	v.Z.Add(&ZZ2, &TT2d)
-	v.T.Subtract(&ZZ2, &TT2d)
+	(*formulas.P1xP1)(v).Add(&p.x, &p.y, &p.z, &p.t, (*formulas.Cached)(q))
 	return v
 }
 
 func (v *projP1xP1) Sub(p *Point, q *projCached) *projP1xP1 {
-	var YplusX, YminusX, PP, MM, TT2d, ZZ2 field.Element
-
-	YplusX.Add(&p.y, &p.x)
-	YminusX.Subtract(&p.y, &p.x)
-
-	PP.Multiply(&YplusX, &q.YminusX) // flipped sign
-	MM.Multiply(&YminusX, &q.YplusX) // flipped sign
-	TT2d.Multiply(&p.t, &q.T2d)
-	ZZ2.Multiply(&p.z, &q.Z)
-
-	ZZ2.Add(&ZZ2, &ZZ2)
-
-	v.X.Subtract(&PP, &MM)
-	v.Y.Add(&PP, &MM)
-	v.Z.Subtract(&ZZ2, &TT2d) // flipped sign
-	v.T.Add(&ZZ2, &TT2d)      // flipped sign
+	(*formulas.P1xP1)(v).Sub(&p.x, &p.y, &p.z, &p.t, (*formulas.Cached)(q))
 	return v
 }
 
 func (v *projP1xP1) AddAffine(p *Point, q *affineCached) *projP1xP1 {
-	var YplusX, YminusX, PP, MM, TT2d, Z2 field.Element
-
-	YplusX.Add(&p.y, &p.x)
-	YminusX.Subtract(&p.y, &p.x)
-
-	PP.Multiply(&YplusX, &q.YplusX)
-	MM.Multiply(&YminusX, &q.YminusX)
-	TT2d.Multiply(&p.t, &q.T2d)
-
-	Z2.Add(&p.z, &p.z)
-
-	v.X.Subtract(&PP, &MM)
-	v.Y.Add(&PP, &MM)
-	v.Z.Add(&Z2, &TT2d)
-	v.T.Subtract(&Z2, &TT2d)
+	(*formulas.P1xP1)(v).AddAffine(&p.x, &p.y, &p.z, &p.t, (*formulas.AffineCached)(q))
 	return v
 }
 
 func (v *projP1xP1) SubAffine(p *Point, q *affineCached) *projP1xP1 {
-	var YplusX, YminusX, PP, MM, TT2d, Z2 field.Element
-
-	YplusX.Add(&p.y, &p.x)
-	YminusX.Subtract(&p.y, &p.x)
-
-	PP.Multiply(&YplusX, &q.YminusX) // flipped sign
-	MM.Multiply(&YminusX, &q.YplusX) // flipped sign
-	TT2d.Multiply(&p.t, &q.T2d)
-
-	Z2.Add(&p.z, &p.z)
-
-	v.X.Subtract(&PP, &MM)
-	v.Y.Add(&PP, &MM)
-	v.Z.Subtract(&Z2, &TT2d) // flipped sign
-	v.T.Add(&Z2, &TT2d)      // flipped sign
+	(*formulas.P1xP1)(v).SubAffine(&p.x, &p.y, &p.z, &p.t, (*formulas.AffineCached)(q))
 	return v
 }
 
 // Doubling.
 
 func (v *projP1xP1) Double(p *projP2) *projP1xP1 {
-	var XX, YY, ZZ2, XplusYsq field.Element
-
-	XX.Square(&p.X)
-	YY.Square(&p.Y)
-	ZZ2.Square(&p.Z)
-	ZZ2.Add(&ZZ2, &ZZ2)
-	XplusYsq.Add(&p.X, &p.Y)
-	XplusYsq.Square(&XplusYsq)
-
-	v.Y.Add(&YY, &XX)
-	v.Z.Subtract(&YY, &XX)
-
-	v.X.Subtract(&XplusYsq, &v.Y)
-	v.T.Subtract(&ZZ2, &v.Z)
+	(*formulas.P1xP1)(v).Double((*formulas.P2)(p))
 	return v
 }
 
@@ -397,31 +315,24 @@ func (v *Point) Equal(u *Point) int {
 
 // Select sets v to a if cond == 1 and to b if cond == 0.
 func (v *projCached) Select(a, b *projCached, cond int) *projCached {
-	v.YplusX.Select(&a.YplusX, &b.YplusX, cond)
-	v.YminusX.Select(&a.YminusX, &b.YminusX, cond)
-	v.Z.Select(&a.Z, &b.Z, cond)
-	v.T2d.Select(&a.T2d, &b.T2d, cond)
+	(*formulas.Cached)(v).Select((*formulas.Cached)(a), (*formulas.Cached)(b), cond)
 	return v
 }
 
 // Select sets v to a if cond == 1 and to b if cond == 0.
 func (v *affineCached) Select(a, b *affineCached, cond int) *affineCached {
-	v.YplusX.Select(&a.YplusX, &b.YplusX, cond)
-	v.YminusX.Select(&a.YminusX, &b.YminusX, cond)
-	v.T2d.Select(&a.T2d, &b.T2d, cond)
+	(*formulas.AffineCached)(v).Select((*formulas.AffineCached)(a), (*formulas.AffineCached)(b), cond)
 	return v
 }
 
 // CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0.
 func (v *projCached) CondNeg(cond int) *projCached {
-	v.YplusX.Swap(&v.YminusX, cond)
-	v.T2d.Select(new(field.Element).Negate(&v.T2d), &v.T2d, cond)
+	(*formulas.Cached)(v).CondNeg(cond)
 	return v
 }
 
 // CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0.
 func (v *affineCached) CondNeg(cond int) *affineCached {
-	v.YplusX.Swap(&v.YminusX, cond)
-	v.T2d.Select(new(field.Element).Negate(&v.T2d), &v.T2d, cond)
+	(*formulas.AffineCached)(v).CondNeg(cond)
 	return v
 }
@@ -272,6 +272,9 @@ func TestNonCanonicalPoints(t *testing.T) {
 			if encoding := hex.EncodeToString(p1.Bytes()); encoding != tt.canonical {
 				t.Errorf("re-encoding does not match canonical; got %q, expected %q", encoding, tt.canonical)
 			}
+			if eq, err := EqualEncodings(decodeHex(tt.encoding), decodeHex(tt.canonical)); err != nil || eq != 1 {
+				t.Errorf("EqualEncodings returned %d, %v", eq, err)
+			}
 			checkOnCurve(t, p1, p2)
 		})
 	}
@@ -8,7 +8,10 @@ package edwards25519
 // upstream crypto/internal/edwards25519 package.
 
 import (
+	"crypto/sha512"
 	"errors"
+	"runtime"
+	"sync"
 
 	"filippo.io/edwards25519/field"
 )
@@ -50,6 +53,49 @@ func (v *Point) SetExtendedCoordinates(X, Y, Z, T *field.Element) (*Point, error
 	return v, nil
 }
 
+var (
+	// ErrNotOnCurve is returned by Validate if the point coordinates don't
+	// satisfy the curve equation.
+	ErrNotOnCurve = errors.New("edwards25519: point is not on the curve")
+
+	// ErrNotTorsionFree is returned by Validate if the point has a small
+	// order component, that is, if it's not in the prime order subgroup.
+	ErrNotTorsionFree = errors.New("edwards25519: point is not in the prime order subgroup")
+
+	// ErrNonCanonicalEncoding is returned by VarTimeValidateEncodings if the
+	// encoding is valid for SetBytes but is not the canonical encoding of the
+	// point according to RFC 8032.
+	ErrNonCanonicalEncoding = errors.New("edwards25519: non-canonical point encoding")
+)
+
+// Validate checks that the extended coordinates of v are consistent and
+// satisfy the curve equation, returning ErrNotOnCurve otherwise. If
+// checkSubgroup is true, Validate also checks that v is in the prime order
+// subgroup, returning ErrNotTorsionFree otherwise.
+//
+// Points produced by this package are always valid, but Validate can be used
+// to audit points constructed through the hazmat package or other unchecked
+// paths. Unlike other methods, Validate returns ErrNotOnCurve rather than
+// panicking for the zero value.
+//
+// The subgroup check costs a scalar multiplication. Validate runs in constant
+// time, except for which error is returned.
+func (v *Point) Validate(checkSubgroup bool) error {
+	var zero field.Element
+	if v.z.Equal(&zero) == 1 || !isOnCurve(&v.x, &v.y, &v.z, &v.t) {
+		return ErrNotOnCurve
+	}
+	if checkSubgroup {
+		// l * v = (l - 1) * v + v is the identity iff v is torsion-free.
+		var p Point
+		p.ScalarMult(scalarMinusOne, v)
+		if p.Add(&p, v).Equal(identity) != 1 {
+			return ErrNotTorsionFree
+		}
+	}
+	return nil
+}
+
 func isOnCurve(X, Y, Z, T *field.Element) bool {
 	var lhs, rhs field.Element
 	XX := new(field.Element).Square(X)
@@ -72,6 +118,254 @@ func isOnCurve(X, Y, Z, T *field.Element) bool {
 	return lhs.Equal(&rhs) == 1
 }
 
+// IsValidYCoordinate reports whether y, a 32-byte encoding, corresponds to the
+// y-coordinate of a point on the curve, that is, whether SetBytes would accept
+// it. As in SetBytes, the most significant bit (the sign of x) is ignored, and
+// non-canonical encodings are accepted.
+//
+// IsValidYCoordinate is faster than SetBytes, as it doesn't compute x.
+func IsValidYCoordinate(y []byte) bool {
+	yy, err := new(field.Element).SetBytes(y)
+	if err != nil {
+		return false
+	}
+
+	// x² = (y² - 1) / (dy² + 1) has a solution iff (y² - 1) * (dy² + 1) is a
+	// square (including zero), which we check with Euler's criterion.
+	var y2, u, v, w, t field.Element
+	y2.Square(yy)
+	u.Subtract(&y2, feOne)            // u = y² - 1
+	v.Multiply(&y2, d).Add(&v, feOne) // v = dy² + 1
+	w.Multiply(&u, &v)                // w = u * v
+	t.Pow22523(&w)                    // t = w^(2^252 - 3)
+	t.Square(&t).Square(&t)           // t = w^(2^254 - 12)
+	t.Multiply(&t, v.Square(&w))      // t = w^(2^254 - 10) = w^((p-1)/2)
+	return t.Equal(feMinusOne) == 0
+}
+
+var feMinusOne = new(field.Element).Negate(feOne)
+
+// VarTimeValidateEncodings checks each of encodings, such as a block's worth
+// of public keys, and reports whether it's the canonical encoding of a point
+// in the prime order subgroup.
+//
+// If all encodings are valid, VarTimeValidateEncodings returns nil. Otherwise,
+// it returns a slice of len(encodings) errors, where errs[i] is nil if
+// encodings[i] is valid, the SetBytes error if it doesn't decode to a point on
+// the curve, ErrNonCanonicalEncoding if it decodes but is not canonical, and
+// ErrNotTorsionFree if the point has a small order component.
+//
+// The subgroup check costs about as much as a VarTimeScalarMult per point.
+// Execution time depends on the inputs.
+func VarTimeValidateEncodings(encodings [][]byte) (errs []error) {
+	// l * P = (l - 1) * P + P is the identity iff P is torsion-free. The NAF
+	// of l - 1 is shared across the batch.
+	naf := scalarMinusOne.nonAdjacentForm(5)
+
+	var p, check Point
+	var table nafLookupTable5
+	var buf [32]byte
+	for i, enc := range encodings {
+		_, err := p.SetBytes(enc)
+		if err == nil && !p.isCanonicalAffineEncoding(&buf, enc) {
+			err = ErrNonCanonicalEncoding
+		}
+		if err == nil {
+			table.FromP3(&p)
+			check.varTimeNafMult(&naf, &table)
+			if check.Add(&check, &p).EqualVarTime(identity) != 1 {
+				err = ErrNotTorsionFree
+			}
+		}
+		if err != nil {
+			if errs == nil {
+				errs = make([]error, len(encodings))
+			}
+			errs[i] = err
+		}
+	}
+	return errs
+}
+
+// isCanonicalAffineEncoding reports whether enc is the canonical encoding of
+// v, which must have Z = 1 as set by SetBytes. It's faster than comparing with
+// Bytes, as it doesn't need a field inversion.
+func (v *Point) isCanonicalAffineEncoding(buf *[32]byte, enc []byte) bool {
+	out := copyFieldElement(buf, &v.y)
+	out[31] |= byte(v.x.IsNegative() << 7)
+	return string(out) == string(enc)
+}
+
+// AddPairwise sets out[i] = a[i] + b[i] for every i. The lengths of out, a, and
+// b must match. out[i] may alias a[i] or b[i].
+func AddPairwise(out, a, b []*Point) {
+	if len(out) != len(a) || len(out) != len(b) {
+		panic("edwards25519: called AddPairwise with different size inputs")
+	}
+	checkInitialized(a...)
+	checkInitialized(b...)
+
+	var cached projCached
+	var result projP1xP1
+	for i := range out {
+		cached.FromP3(b[i])
+		result.Add(a[i], &cached)
+		out[i].fromP1xP1(&result)
+	}
+}
+
+// EqualModTorsion returns 1 if 8 * v is equivalent to 8 * u, and 0 otherwise.
+// That is, it reports whether v and u differ only by a small order component,
+// as required by cofactored verification equations.
+func (v *Point) EqualModTorsion(u *Point) int {
+	checkInitialized(v, u)
+	var diff Point
+	diff.Subtract(v, u)
+	diff.MultByCofactor(&diff)
+	return diff.Equal(identity)
+}
+
+// fingerprintDomain separates Fingerprint from other uses of SHA-512.
+const fingerprintDomain = "filippo.io/edwards25519 point fingerprint v1"
+
+// Fingerprint returns a short identifier for v, made of the first n bytes of
+// SHA-512(domain || v.Bytes()), where domain is the ASCII string
+// "filippo.io/edwards25519 point fingerprint v1". It panics if n is not between
+// 1 and 64.
+//
+// Equivalent points have the same fingerprint. Collision resistance depends on
+// n: 16 bytes are sufficient for most uses as identifiers in logs and maps.
+func (v *Point) Fingerprint(n int) []byte {
+	if n < 1 || n > sha512.Size {
+		panic("edwards25519: invalid fingerprint length")
+	}
+	var buf [32]byte
+	h := sha512.New()
+	h.Write([]byte(fingerprintDomain))
+	h.Write(v.bytes(&buf))
+	return h.Sum(nil)[:n]
+}
+
+// Reset sets v to the identity, overwriting its previous value. It is meant
+// for recycling Points, for example through a sync.Pool, without retaining
+// their previous values.
+//
+// Like Scalar.Reset, it can also be used to scrub secret points once they
+// are no longer needed.
+func (v *Point) Reset() {
+	v.Set(identity)
+}
+
+// EqualVarTime returns 1 if v is equivalent to u, and 0 otherwise.
+//
+// Execution time depends on the inputs, and EqualVarTime returns as soon as it
+// finds a difference. It must only be used with public values.
+func (v *Point) EqualVarTime(u *Point) int {
+	checkInitialized(v, u)
+	if v == u {
+		return 1
+	}
+
+	var t1, t2 field.Element
+	t1.Multiply(&v.x, &u.z)
+	t2.Multiply(&u.x, &v.z)
+	if t1.EqualVarTime(&t2) != 1 {
+		return 0
+	}
+	t1.Multiply(&v.y, &u.z)
+	t2.Multiply(&u.y, &v.z)
+	if t1.EqualVarTime(&t2) != 1 {
+		return 0
+	}
+	return 1
+}
+
+// EqualEncodings returns 1 if a and b are encodings of the same point, and 0
+// otherwise. If either a or b is not a valid encoding, EqualEncodings returns
+// an error.
+//
+// Encodings are decoded according to the rules of SetBytes, which accepts
+// non-canonical encodings as required by ZIP-215, so different encodings of
+// the same point compare as equal.
+func EqualEncodings(a, b []byte) (int, error) {
+	p, err := new(Point).SetBytes(a)
+	if err != nil {
+		return 0, err
+	}
+	q, err := new(Point).SetBytes(b)
+	if err != nil {
+		return 0, err
+	}
+	return p.Equal(q), nil
+}
+
+// XSignBit returns the sign of the x coordinate of v, that is, 1 if the
+// canonical encoding of x is odd and 0 otherwise. This is the most significant
+// bit of the encoding returned by Bytes.
+//
+// XSignBit requires a field inversion, and runs in constant time.
+func (v *Point) XSignBit() int {
+	checkInitialized(v)
+
+	var zInv, x field.Element
+	zInv.Invert(&v.z)       // zInv = 1 / Z
+	x.Multiply(&v.x, &zInv) // x = X / Z
+	return x.IsNegative()
+}
+
+// AffineBytes returns the affine coordinates of v as a 64-byte slice, x || y,
+// where each coordinate is the canonical 32-byte little-endian encoding of a
+// field element, as returned by field.Element.Bytes. This is the format
+// commonly expected by zero-knowledge circuit toolchains.
+func (v *Point) AffineBytes() []byte {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var buf [64]byte
+	return v.affineBytes(&buf)
+}
+
+func (v *Point) affineBytes(buf *[64]byte) []byte {
+	checkInitialized(v)
+
+	var zInv, x, y field.Element
+	zInv.Invert(&v.z)       // zInv = 1 / Z
+	x.Multiply(&v.x, &zInv) // x = X / Z
+	y.Multiply(&v.y, &zInv) // y = Y / Z
+
+	copyFieldElement((*[32]byte)(buf[:32]), &x)
+	copyFieldElement((*[32]byte)(buf[32:]), &y)
+	return buf[:]
+}
+
+// SetAffineBytes sets v to the point with affine coordinates x || y, as
+// returned by AffineBytes, and returns v.
+//
+// If b is not 64 bytes long, if either coordinate is not a canonical
+// encoding, or if the coordinates are not on the curve, SetAffineBytes returns
+// nil and an error, and the receiver is unchanged.
+func (v *Point) SetAffineBytes(b []byte) (*Point, error) {
+	if len(b) != 64 {
+		return nil, errors.New("edwards25519: invalid affine point encoding length")
+	}
+	var x, y, xy field.Element
+	var buf [32]byte
+	if _, err := x.SetBytes(b[:32]); err != nil || string(copyFieldElement(&buf, &x)) != string(b[:32]) {
+		return nil, errors.New("edwards25519: non-canonical affine x coordinate")
+	}
+	if _, err := y.SetBytes(b[32:]); err != nil || string(copyFieldElement(&buf, &y)) != string(b[32:]) {
+		return nil, errors.New("edwards25519: non-canonical affine y coordinate")
+	}
+	xy.Multiply(&x, &y)
+	if !isOnCurve(&x, &y, feOne, &xy) {
+		return nil, errors.New("edwards25519: affine coordinates are not on the curve")
+	}
+	v.x.Set(&x)
+	v.y.Set(&y)
+	v.z.One()
+	v.t.Set(&xy)
+	return v, nil
+}
+
 // BytesMontgomery converts v to a point on the birationally-equivalent
 // Curve25519 Montgomery curve, and returns its canonical 32 bytes encoding
 // according to RFC 7748.
@@ -111,19 +405,267 @@ func (v *Point) bytesMontgomery(buf *[32]byte) []byte {
 	return copyFieldElement(buf, &u)
 }
 
+// AppendBinary appends the canonical 32-byte encoding of v, as returned by
+// Bytes, to b and returns the extended buffer. It implements the
+// encoding.BinaryAppender interface, and never returns an error.
+func (v *Point) AppendBinary(b []byte) ([]byte, error) {
+	var buf [32]byte
+	return append(b, v.bytes(&buf)...), nil
+}
+
+// Double sets v = 2 * p, and returns v.
+//
+// Double uses a dedicated doubling formula, which is faster than Add(p, p).
+func (v *Point) Double(p *Point) *Point {
+	checkInitialized(p)
+	pp := new(projP2).FromP3(p)
+	result := new(projP1xP1).Double(pp)
+	return v.fromP1xP1(result)
+}
+
+// Triple sets v = 3 * p, and returns v.
+//
+// Triple uses a dedicated tripling formula, which is faster than Double
+// followed by Add.
+func (v *Point) Triple(p *Point) *Point {
+	checkInitialized(p)
+
+	// This is tpl-2015-c from https://hyperelliptic.org/EFD/g1p/auto-twisted-extended.html#tripling-tpl-2015-c
+	// with a = -1, costing 11M + 3S instead of the 13M + 4S of a doubling and
+	// an addition.
+	var YY, XX, Ap, B, xB, yB, AA, F, G, xE, yH, zF, zG field.Element
+
+	YY.Square(&p.y)
+	XX.Square(&p.x) // aXX = -XX
+	Ap.Subtract(&YY, &XX)
+	B.Square(&p.z)
+	B.Add(&B, &B)
+	B.Subtract(&B, &Ap)
+	B.Add(&B, &B)
+	xB.Multiply(&XX, &B) // -aXX * B
+	yB.Multiply(&YY, &B)
+	AA.Add(&YY, &XX)
+	AA.Multiply(&AA, &Ap)
+	F.Subtract(&AA, &yB)
+	G.Subtract(&AA, &xB)
+	xE.Add(&yB, &AA)
+	xE.Multiply(&xE, &p.x)
+	yH.Add(&xB, &AA)
+	yH.Multiply(&yH, &p.y)
+	yH.Negate(&yH) // yH = Y1 * (aXX * B - AA)
+	zF.Multiply(&p.z, &F)
+	zG.Multiply(&p.z, &G)
+
+	v.x.Multiply(&xE, &zF)
+	v.y.Multiply(&yH, &zG)
+	v.z.Multiply(&zF, &zG)
+	v.t.Multiply(&xE, &yH)
+	return v
+}
+
 // MultByCofactor sets v = 8 * p, and returns v.
 func (v *Point) MultByCofactor(p *Point) *Point {
+	return v.MultByPow2(p, 3)
+}
+
+// MultByPow2 sets v = 2^k * p, and returns v. It panics if k is negative.
+//
+// MultByPow2 is faster than k calls to Double, as intermediate values are kept
+// in projective coordinates.
+func (v *Point) MultByPow2(p *Point, k int) *Point {
 	checkInitialized(p)
+	if k < 0 {
+		panic("edwards25519: negative exponent passed to MultByPow2")
+	}
+	if k == 0 {
+		return v.Set(p)
+	}
 	result := projP1xP1{}
 	pp := (&projP2{}).FromP3(p)
 	result.Double(pp)
-	pp.FromP1xP1(&result)
-	result.Double(pp)
-	pp.FromP1xP1(&result)
-	result.Double(pp)
+	for i := 1; i < k; i++ {
+		pp.FromP1xP1(&result)
+		result.Double(pp)
+	}
 	return v.fromP1xP1(&result)
 }
 
+// ScalarBaseMultWithDoublings sets v = x * B, where B is the canonical
+// generator, sets doublings[i] = 2^(i+1) * x * B for every non-nil entry of
+// doublings, and returns v.
+//
+// The multiples are computed by repeatedly doubling x * B, so they are cheaper
+// than a separate scalar multiplication each. Nil entries are skipped, saving
+// the conversion of the intermediate value, but not its doubling.
+//
+// The scalar multiplication is done in constant time. The receiver and the
+// entries of doublings may alias.
+func (v *Point) ScalarBaseMultWithDoublings(x *Scalar, doublings []*Point) *Point {
+	var xB Point
+	xB.ScalarBaseMult(x)
+
+	result := projP1xP1{}
+	pp := (&projP2{}).FromP3(&xB)
+	for _, d := range doublings {
+		result.Double(pp)
+		pp.FromP1xP1(&result)
+		if d != nil {
+			d.fromP1xP1(&result)
+		}
+	}
+
+	return v.Set(&xB)
+}
+
+// scalarInverseEightBytes is the little-endian encoding of 8⁻¹ mod l.
+var scalarInverseEightBytes = [32]byte{121, 47, 220, 226, 41, 229, 6, 97, 208, 218, 28, 125, 179, 157, 211, 7, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 6}
+
+var scalarInverseEight, _ = new(Scalar).SetCanonicalBytes(scalarInverseEightBytes[:])
+
+// TorsionComponent sets v to the 8-torsion component of p, and returns v.
+//
+// Every point P can be uniquely written as P = A + T, where A is in the prime
+// order subgroup and T is in the 8-torsion subgroup. TorsionComponent returns
+// T, which is the identity if and only if p is torsion-free.
+func (v *Point) TorsionComponent(p *Point) *Point {
+	checkInitialized(p)
+	var a Point
+	a.PrimeOrderComponent(p)
+	return v.Subtract(p, &a)
+}
+
+// PrimeOrderComponent sets v to the prime order component of p, and returns v.
+//
+// Every point P can be uniquely written as P = A + T, where A is in the prime
+// order subgroup and T is in the 8-torsion subgroup. PrimeOrderComponent
+// returns A, clearing the torsion component without changing the discrete
+// logarithm: if P = s * B + T, then A = s * B. In contrast, MultByCofactor
+// returns 8 * A.
+func (v *Point) PrimeOrderComponent(p *Point) *Point {
+	checkInitialized(p)
+	// 8 * P = 8 * A, since 8 * T is the identity, so A = 8⁻¹ * (8 * P).
+	var p8 Point
+	p8.MultByCofactor(p)
+	return v.MultByCofactorInverse(&p8)
+}
+
+// MultByCofactorInverse sets v = 8⁻¹ * p, where 8⁻¹ is the inverse of the
+// cofactor modulo l, and returns v.
+//
+// If p is in the prime order subgroup, MultByCofactorInverse undoes
+// MultByCofactor. Otherwise, the result is not the inverse of MultByCofactor,
+// as the torsion component of p is multiplied by 8⁻¹ mod l, which is not the
+// inverse of 8 in the 8-torsion subgroup.
+//
+// The scalar multiplication is done in constant time.
+func (v *Point) MultByCofactorInverse(p *Point) *Point {
+	return v.ScalarMult(scalarInverseEight, p)
+}
+
+// ScalarMultWithClamping applies the buffer pruning described in RFC 8032,
+// Section 5.1.5 (also known as clamping) to x, sets v = x * q, and returns v.
+// The input x must be 32 bytes, and it is not modified. If x is not of the
+// right length, ScalarMultWithClamping returns nil and an error, and the
+// receiver is unchanged.
+//
+// Unlike SetBytesWithClamping followed by ScalarMult, ScalarMultWithClamping
+// multiplies by the clamped value as an integer, not reduced modulo l, so it
+// preserves the cofactor-clearing property of clamping: the result is always
+// in the prime order subgroup, like in X25519.
+//
+// The scalar multiplication is done in constant time.
+func (v *Point) ScalarMultWithClamping(x []byte, q *Point) (*Point, error) {
+	if len(x) != 32 {
+		return nil, errors.New("edwards25519: invalid ScalarMultWithClamping input length")
+	}
+	checkInitialized(q)
+
+	// The clamped value is 8 * n with 2^251 <= n < 2^252 < l, so n is a
+	// canonical scalar and x * q = 8 * (n * q). This costs a regular scalar
+	// multiplication plus three doublings, which is about the same as
+	// SetBytesWithClamping followed by ScalarMult.
+	var n [32]byte
+	for i := 0; i < 31; i++ {
+		n[i] = x[i]>>3 | x[i+1]<<5
+	}
+	n[31] = x[31] >> 3
+	n[31] &= 63 >> 3
+	n[31] |= 64 >> 3
+	s, err := new(Scalar).SetCanonicalBytes(n[:])
+	if err != nil {
+		panic("edwards25519: internal error: clamped scalar is not reduced")
+	}
+	v.ScalarMult(s, q)
+	return v.MultByCofactor(v), nil
+}
+
+// ScalarMultBytes sets v = x * q, where x is a 32-byte little-endian integer,
+// and returns v. If x is not of the right length, ScalarMultBytes returns nil
+// and an error, and the receiver is unchanged.
+//
+// Unlike ScalarMult, x is not reduced modulo l, so if q is not in the prime
+// order subgroup, the result matches X25519-style multiplication by the full
+// 256-bit integer. If x is a multiple of 8, the result is always in the prime
+// order subgroup.
+//
+// The scalar multiplication is done in constant time.
+func (v *Point) ScalarMultBytes(x []byte, q *Point) (*Point, error) {
+	if len(x) != 32 {
+		return nil, errors.New("edwards25519: invalid ScalarMultBytes input length")
+	}
+	checkInitialized(q)
+
+	var table projLookupTable
+	table.FromP3(q)
+
+	// Proceed as in ScalarMult, with one extra digit for the top carry.
+	var digits [65]int8
+	for i := 0; i < 32; i++ {
+		digits[2*i] = int8(x[i] & 15)
+		digits[2*i+1] = int8((x[i] >> 4) & 15)
+	}
+	for i := 0; i < 64; i++ {
+		carry := (digits[i] + 8) >> 4
+		digits[i] -= carry << 4
+		digits[i+1] += carry
+	}
+
+	multiple := &projCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	table.SelectInto(multiple, digits[64])
+
+	v.Set(NewIdentityPoint())
+	tmp1.Add(v, multiple) // tmp1 = x_64*Q in P1xP1 coords
+	for i := 63; i >= 0; i-- {
+		tmp2.FromP1xP1(tmp1) // tmp2 =    (prev) in P2 coords
+		tmp1.Double(tmp2)    // tmp1 =  2*(prev) in P1xP1 coords
+		tmp2.FromP1xP1(tmp1) // tmp2 =  2*(prev) in P2 coords
+		tmp1.Double(tmp2)    // tmp1 =  4*(prev) in P1xP1 coords
+		tmp2.FromP1xP1(tmp1) // tmp2 =  4*(prev) in P2 coords
+		tmp1.Double(tmp2)    // tmp1 =  8*(prev) in P1xP1 coords
+		tmp2.FromP1xP1(tmp1) // tmp2 =  8*(prev) in P2 coords
+		tmp1.Double(tmp2)    // tmp1 = 16*(prev) in P1xP1 coords
+		v.fromP1xP1(tmp1)    //    v = 16*(prev) in P3 coords
+		table.SelectInto(multiple, digits[i])
+		tmp1.Add(v, multiple) // tmp1 = x_i*Q + 16*(prev) in P1xP1 coords
+	}
+	v.fromP1xP1(tmp1)
+	return v, nil
+}
+
+// SetFieldElement sets s = x mod l, where x is interpreted as the integer in
+// [0, p) represented by its canonical encoding, and returns s.
+//
+// Since p > l, distinct field elements can map to the same scalar. This is
+// the conversion used, for example, by protocols that hash or reinterpret an
+// x-coordinate as a challenge. The computation is done in constant time.
+func (s *Scalar) SetFieldElement(x *field.Element) *Scalar {
+	var wide [64]byte
+	copy(wide[:], x.Bytes())
+	return s.SetWideBytes(&wide)
+}
+
 // Given k > 0, set s = s**(2*k).
 func (s *Scalar) pow2k(k int) {
 	for i := 0; i < k; i++ {
@@ -135,6 +677,22 @@ func (s *Scalar) pow2k(k int) {
 //
 // If t is zero, Invert returns zero.
 func (s *Scalar) Invert(t *Scalar) *Scalar {
+	return s.invertSafegcd(t)
+}
+
+// InvertVarTime sets s to the inverse of a nonzero scalar t, and returns s.
+// If t is zero, InvertVarTime returns zero.
+//
+// InvertVarTime is faster than Invert, but its execution time depends on t,
+// so it must only be used with public values, such as when computing Lagrange
+// coefficients at public indices.
+func (s *Scalar) InvertVarTime(t *Scalar) *Scalar {
+	return s.invertSafegcdVarTime(t)
+}
+
+// invertChain works like Invert, but is slower. It's used to test
+// invertSafegcd.
+func (s *Scalar) invertChain(t *Scalar) *Scalar {
 	// Uses a hardcoded sliding window of width 4.
 	var table [8]Scalar
 	var tt Scalar
@@ -237,6 +795,10 @@ func (s *Scalar) Invert(t *Scalar) *Scalar {
 	return s
 }
 
+// msmStackPoints is the number of points up to which the multi-scalar
+// multiplication functions don't allocate.
+const msmStackPoints = 4
+
 // MultiScalarMult sets v = sum(scalars[i] * points[i]), and returns v.
 //
 // Execution time depends only on the lengths of the two slices, which must match.
@@ -245,25 +807,52 @@ func (v *Point) MultiScalarMult(scalars []*Scalar, points []*Point) *Point {
 		panic("edwards25519: called MultiScalarMult with different size inputs")
 	}
 	checkInitialized(points...)
+	return v.multiScalarMult(&msmTerms{scalars: scalars, points: points}, nil)
+}
+
+// MultiScalarMultValues sets v = sum(scalars[i] * points[i]), and returns v.
+//
+// It works like MultiScalarMult, but takes slices of values, which avoids
+// the indirections and allocations of slices of pointers when the caller
+// already holds the inputs in contiguous memory.
+//
+// Execution time depends only on the lengths of the two slices, which must match.
+func (v *Point) MultiScalarMultValues(scalars []Scalar, points []Point) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called MultiScalarMultValues with different size inputs")
+	}
+	for i := range points {
+		checkInitialized(&points[i])
+	}
+	return v.multiScalarMult(&msmTerms{values: true, scalarValues: scalars, pointValues: points}, nil)
+}
 
+func (v *Point) multiScalarMult(terms *msmTerms, scratch *MSMScratch) *Point {
 	// Proceed as in the single-base case, but share doublings
 	// between each point in the multiscalar equation.
 
-	// Build lookup tables for each point
-	tables := make([]projLookupTable, len(points))
+	// Build lookup tables for each point, using stack space for small inputs
+	n := terms.len()
+	var tablesBuf [msmStackPoints]projLookupTable
+	var digitsBuf [msmStackPoints][64]int8
+	tables, digits := tablesBuf[:], digitsBuf[:]
+	if n > msmStackPoints {
+		tables, digits = scratch.projTables(n), scratch.radix16Digits(n)
+	}
+	tables, digits = tables[:n], digits[:n]
 	for i := range tables {
-		tables[i].FromP3(points[i])
+		tables[i].FromP3(terms.point(i))
 	}
 	// Compute signed radix-16 digits for each scalar
-	digits := make([][64]int8, len(scalars))
 	for i := range digits {
-		digits[i] = scalars[i].signedRadix16()
+		digits[i] = terms.scalar(i).signedRadix16()
 	}
 
 	// Unwrap first loop iteration to save computing 16*identity
 	multiple := &projCached{}
 	tmp1 := &projP1xP1{}
 	tmp2 := &projP2{}
+	v.Set(NewIdentityPoint())
 	// Lookup-and-add the appropriate multiple of each input point
 	for j := range tables {
 		tables[j].SelectInto(multiple, digits[j][63])
@@ -291,42 +880,264 @@ func (v *Point) MultiScalarMult(scalars []*Scalar, points []*Point) *Point {
 	return v
 }
 
+// DoubleScalarMult sets v = a * A + b * B, and returns v.
+//
+// The scalar multiplication is done in constant time. It is faster than two
+// separate calls to ScalarMult, as the doublings are shared.
+func (v *Point) DoubleScalarMult(a *Scalar, A *Point, b *Scalar, B *Point) *Point {
+	checkInitialized(A, B)
+
+	// Proceed as in MultiScalarMult, without allocating slices.
+	var tableA, tableB projLookupTable
+	tableA.FromP3(A)
+	tableB.FromP3(B)
+	aDigits := a.signedRadix16()
+	bDigits := b.signedRadix16()
+
+	multiple := &projCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	v.Set(NewIdentityPoint())
+	for i := 63; i >= 0; i-- {
+		if i < 63 {
+			tmp2.FromP3(v)       // tmp2 =    (prev) in P2 coords
+			tmp1.Double(tmp2)    // tmp1 =  2*(prev) in P1xP1 coords
+			tmp2.FromP1xP1(tmp1) // tmp2 =  2*(prev) in P2 coords
+			tmp1.Double(tmp2)    // tmp1 =  4*(prev) in P1xP1 coords
+			tmp2.FromP1xP1(tmp1) // tmp2 =  4*(prev) in P2 coords
+			tmp1.Double(tmp2)    // tmp1 =  8*(prev) in P1xP1 coords
+			tmp2.FromP1xP1(tmp1) // tmp2 =  8*(prev) in P2 coords
+			tmp1.Double(tmp2)    // tmp1 = 16*(prev) in P1xP1 coords
+			v.fromP1xP1(tmp1)    //    v = 16*(prev) in P3 coords
+		}
+		tableA.SelectInto(multiple, aDigits[i])
+		tmp1.Add(v, multiple) // tmp1 = v + a_i*A in P1xP1 coords
+		v.fromP1xP1(tmp1)
+		tableB.SelectInto(multiple, bDigits[i])
+		tmp1.Add(v, multiple) // tmp1 = v + b_i*B in P1xP1 coords
+		v.fromP1xP1(tmp1)
+	}
+	return v
+}
+
+// CheckedMultiScalarMult works like MultiScalarMult, but if the slices have
+// different lengths, or if any of the scalars or points is nil or
+// uninitialized, it returns nil and an error instead of panicking, and the
+// receiver is unchanged.
+func (v *Point) CheckedMultiScalarMult(scalars []*Scalar, points []*Point) (*Point, error) {
+	if err := checkMultiScalarMultInputs(scalars, points); err != nil {
+		return nil, err
+	}
+	return v.MultiScalarMult(scalars, points), nil
+}
+
 // VarTimeMultiScalarMult sets v = sum(scalars[i] * points[i]), and returns v.
 //
+// It automatically picks the fastest algorithm for the size of the inputs,
+// and scales to millions of points.
+//
 // Execution time depends on the inputs.
 func (v *Point) VarTimeMultiScalarMult(scalars []*Scalar, points []*Point) *Point {
 	if len(scalars) != len(points) {
 		panic("edwards25519: called VarTimeMultiScalarMult with different size inputs")
 	}
 	checkInitialized(points...)
+	return v.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, nil, nil)
+}
 
+// VarTimeMultiScalarMultValues sets v = sum(scalars[i] * points[i]), and
+// returns v.
+//
+// It works like VarTimeMultiScalarMult, but takes slices of values, like
+// MultiScalarMultValues.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultValues(scalars []Scalar, points []Point) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called VarTimeMultiScalarMultValues with different size inputs")
+	}
+	for i := range points {
+		checkInitialized(&points[i])
+	}
+	return v.varTimeMultiScalarMult(&msmTerms{values: true, scalarValues: scalars, pointValues: points}, nil, nil)
+}
+
+// VarTimeMultiScalarMultEncoded sets v = sum(scalars[i] * P[i]), where P[i]
+// is the point encoded in encodings[i], and returns v. The lengths of scalars
+// and encodings must match.
+//
+// The encodings are decoded following the same rules as SetBytes. If any of
+// them is invalid, VarTimeMultiScalarMultEncoded returns nil and a slice of
+// len(encodings) errors, where errs[i] is non-nil if encodings[i] is invalid,
+// and the receiver is unchanged. Otherwise, errs is nil.
+//
+// Unlike encoding, decoding can't share work across points: it doesn't need a
+// field inversion, as SqrtRatio folds it into a single exponentiation per
+// point. VarTimeMultiScalarMultEncoded is only a convenience wrapper.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultEncoded(scalars []*Scalar, encodings [][]byte) (_ *Point, errs []error) {
+	if len(scalars) != len(encodings) {
+		panic("edwards25519: called VarTimeMultiScalarMultEncoded with different size inputs")
+	}
+	points := make([]*Point, len(encodings))
+	values := make([]Point, len(encodings))
+	for i, enc := range encodings {
+		points[i] = &values[i]
+		if _, err := points[i].SetBytes(enc); err != nil {
+			if errs == nil {
+				errs = make([]error, len(encodings))
+			}
+			errs[i] = err
+		}
+	}
+	if errs != nil {
+		return nil, errs
+	}
+	return v.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, nil, nil), nil
+}
+
+// VarTimeMultiScalarBaseMult sets v = sum(scalars[i] * points[i]) + b * B,
+// where B is the canonical generator, and returns v.
+//
+// It is faster than including B in the inputs of VarTimeMultiScalarMult, as
+// it uses a larger precomputed table for the fixed base.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarBaseMult(scalars []*Scalar, points []*Point, b *Scalar) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called VarTimeMultiScalarBaseMult with different size inputs")
+	}
+	checkInitialized(points...)
+	return v.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, b, nil)
+}
+
+// VarTimeMultiScalarMultParallel sets v = sum(scalars[i] * points[i]), and
+// returns v, like VarTimeMultiScalarMult.
+//
+// The inputs are split into up to workers contiguous chunks, whose partial
+// sums are computed concurrently by separate goroutines. If workers is zero,
+// runtime.GOMAXPROCS(0) is used. If workers is one, the computation is done
+// by the calling goroutine. The speedup is worth the overhead only for large
+// inputs, in the order of thousands of points.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultParallel(scalars []*Scalar, points []*Point, workers int) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called VarTimeMultiScalarMultParallel with different size inputs")
+	}
+	checkInitialized(points...)
+
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(points) {
+		workers = len(points)
+	}
+	if workers <= 1 {
+		return v.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, nil, nil)
+	}
+
+	chunk := (len(points) + workers - 1) / workers
+	partials := make([]Point, (len(points)+chunk-1)/chunk)
+	var wg sync.WaitGroup
+	for i := range partials {
+		start, end := i*chunk, (i+1)*chunk
+		if end > len(points) {
+			end = len(points)
+		}
+		wg.Add(1)
+		go func(p *Point, scalars []*Scalar, points []*Point) {
+			defer wg.Done()
+			p.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, nil, nil)
+		}(&partials[i], scalars[start:end], points[start:end])
+	}
+	wg.Wait()
+
+	v.Set(&partials[0])
+	for i := 1; i < len(partials); i++ {
+		v.Add(v, &partials[i])
+	}
+	return v
+}
+
+// pippengerThreshold is the number of points from which varTimeMultiScalarMult
+// switches from Straus' method to Pippenger's method, measured on amd64.
+const pippengerThreshold = 190
+
+// varTimeMultiScalarMult sets v = sum(terms) + b * B, and returns v. If b is
+// nil, the basepoint term is omitted.
+//
+// It uses Straus' method for small inputs, and Pippenger's method for large
+// ones, where the basepoint is handled like any other point.
+func (v *Point) varTimeMultiScalarMult(terms *msmTerms, b *Scalar, scratch *MSMScratch) *Point {
+	if terms.len() < pippengerThreshold {
+		return v.varTimeStraus(terms, b, scratch)
+	}
+	n := terms.len()
+	if b != nil {
+		n++
+	}
+	return v.varTimePippenger(terms, b, pippengerWindow(n), scratch)
+}
+
+// varTimeStraus sets v = sum(terms) + b * B using Straus' method, and returns
+// v. If b is nil, the basepoint term is omitted.
+func (v *Point) varTimeStraus(terms *msmTerms, b *Scalar, scratch *MSMScratch) *Point {
 	// Generalize double-base NAF computation to arbitrary sizes.
 	// Here all the points are dynamic, so we only use the smaller
 	// tables.
 
-	// Build lookup tables for each point
-	tables := make([]nafLookupTable5, len(points))
+	// Build lookup tables for each point, using stack space for small inputs
+	n := terms.len()
+	var tablesBuf [msmStackPoints]nafLookupTable5
+	var nafsBuf [msmStackPoints][256]int8
+	tables, nafs := tablesBuf[:], nafsBuf[:]
+	if n > msmStackPoints {
+		tables, nafs = scratch.nafTables(n), scratch.nafDigits(n)
+	}
+	tables, nafs = tables[:n], nafs[:n]
 	for i := range tables {
-		tables[i].FromP3(points[i])
+		tables[i].FromP3(terms.point(i))
 	}
 	// Compute a NAF for each scalar
-	nafs := make([][256]int8, len(scalars))
 	for i := range nafs {
-		nafs[i] = scalars[i].nonAdjacentForm(5)
+		nafs[i] = terms.scalar(i).nonAdjacentForm(5)
+	}
+	// The basepoint is fixed, so we can use a wider NAF corresponding to a
+	// bigger precomputed table.
+	var bNaf [256]int8
+	var bTable *nafLookupTable8
+	if b != nil {
+		bNaf = b.nonAdjacentForm(8)
+		bTable = basepointNafTable()
 	}
 
 	multiple := &projCached{}
+	multB := &affineCached{}
 	tmp1 := &projP1xP1{}
 	tmp2 := &projP2{}
 	tmp2.Zero()
 
+	// Find the first nonzero coefficient, to skip the leading doublings when
+	// all the scalars are short, such as small public coefficients.
+	top := -1
+	for i := 255; i >= 0 && top < 0; i-- {
+		if bNaf[i] != 0 {
+			top = i
+		}
+		for j := range nafs {
+			if nafs[j][i] != 0 {
+				top = i
+				break
+			}
+		}
+	}
+
 	// Move from high to low bits, doubling the accumulator
 	// at each iteration and checking whether there is a nonzero
 	// coefficient to look up a multiple of.
-	//
-	// Skip trying to find the first nonzero coefficent, because
-	// searching might be more work than a few extra doublings.
-	for i := 255; i >= 0; i-- {
+	for i := top; i >= 0; i-- {
 		tmp1.Double(tmp2)
 
 		for j := range nafs {
@@ -341,9 +1152,353 @@ func (v *Point) VarTimeMultiScalarMult(scalars []*Scalar, points []*Point) *Poin
 			}
 		}
 
+		if bNaf[i] > 0 {
+			v.fromP1xP1(tmp1)
+			bTable.SelectInto(multB, bNaf[i])
+			tmp1.AddAffine(v, multB)
+		} else if bNaf[i] < 0 {
+			v.fromP1xP1(tmp1)
+			bTable.SelectInto(multB, -bNaf[i])
+			tmp1.SubAffine(v, multB)
+		}
+
+		tmp2.FromP1xP1(tmp1)
+	}
+
+	v.fromP2(tmp2)
+	return v
+}
+
+// VarTimeScalarMult sets v = x * q, and returns v.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeScalarMult(x *Scalar, q *Point) *Point {
+	checkInitialized(q)
+
+	var table nafLookupTable5
+	table.FromP3(q)
+	naf := x.nonAdjacentForm(5)
+	return v.varTimeNafMult(&naf, &table)
+}
+
+// varTimeNafMult sets v to the multiple of the point precomputed in table
+// with width-5 NAF naf, and returns v.
+func (v *Point) varTimeNafMult(naf *[256]int8, table *nafLookupTable5) *Point {
+	// Skip the leading zero coefficients.
+	i := 255
+	for i >= 0 && naf[i] == 0 {
+		i--
+	}
+
+	multiple := &projCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	tmp2.Zero()
+
+	for ; i >= 0; i-- {
+		tmp1.Double(tmp2)
+
+		if naf[i] > 0 {
+			v.fromP1xP1(tmp1)
+			table.SelectInto(multiple, naf[i])
+			tmp1.Add(v, multiple)
+		} else if naf[i] < 0 {
+			v.fromP1xP1(tmp1)
+			table.SelectInto(multiple, -naf[i])
+			tmp1.Sub(v, multiple)
+		}
+
+		tmp2.FromP1xP1(tmp1)
+	}
+
+	v.fromP2(tmp2)
+	return v
+}
+
+// VarTimeScalarBaseMult sets v = x * B, where B is the canonical generator,
+// and returns v.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeScalarBaseMult(x *Scalar) *Point {
+	basepointTable := basepointTable()
+
+	// Proceed as in ScalarBaseMult, but index the tables directly instead of
+	// scanning them in constant time, and skip zero digits. This is faster
+	// than a NAF with the wider basepointNafTable, which requires a doubling
+	// for every bit of the scalar.
+	digits := x.signedRadix16()
+
+	tmp1 := &projP1xP1{}
+
+	v.Set(NewIdentityPoint())
+	for r := basepointCombSpacing - 1; r >= 0; r-- {
+		for j := range basepointTable {
+			v.varTimeAddBasepointMultiple(tmp1, &basepointTable[j], digits[j*basepointCombSpacing+r])
+		}
+		if r > 0 {
+			v.MultByPow2(v, 4)
+		}
+	}
+
+	return v
+}
+
+// varTimeAddBasepointMultiple sets v = v + d * Q, where table is the
+// affineLookupTable of Q and -8 <= d <= 8, using tmp as scratch space.
+func (v *Point) varTimeAddBasepointMultiple(tmp *projP1xP1, table *affineLookupTable, d int8) {
+	switch {
+	case d > 0:
+		tmp.AddAffine(v, &table.points[d-1])
+	case d < 0:
+		tmp.SubAffine(v, &table.points[-d-1])
+	default:
+		return
+	}
+	v.fromP1xP1(tmp)
+}
+
+// VarTimeTripleScalarBaseMult sets v = a * P + b * Q + c * B, where B is the
+// canonical generator, and returns v.
+//
+// It is equivalent to VarTimeMultiScalarBaseMult with two points, but it
+// doesn't allocate. This shape is common in ring signature verification.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeTripleScalarBaseMult(a *Scalar, P *Point, b *Scalar, Q *Point, c *Scalar) *Point {
+	checkInitialized(P, Q)
+
+	var pTable, qTable nafLookupTable5
+	pTable.FromP3(P)
+	qTable.FromP3(Q)
+	bTable := basepointNafTable()
+
+	aNaf := a.nonAdjacentForm(5)
+	bNaf := b.nonAdjacentForm(5)
+	cNaf := c.nonAdjacentForm(8)
+
+	// Skip the leading zero coefficients.
+	i := 255
+	for i >= 0 && aNaf[i] == 0 && bNaf[i] == 0 && cNaf[i] == 0 {
+		i--
+	}
+
+	multiple := &projCached{}
+	multB := &affineCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	tmp2.Zero()
+
+	for ; i >= 0; i-- {
+		tmp1.Double(tmp2)
+
+		if aNaf[i] > 0 {
+			v.fromP1xP1(tmp1)
+			pTable.SelectInto(multiple, aNaf[i])
+			tmp1.Add(v, multiple)
+		} else if aNaf[i] < 0 {
+			v.fromP1xP1(tmp1)
+			pTable.SelectInto(multiple, -aNaf[i])
+			tmp1.Sub(v, multiple)
+		}
+
+		if bNaf[i] > 0 {
+			v.fromP1xP1(tmp1)
+			qTable.SelectInto(multiple, bNaf[i])
+			tmp1.Add(v, multiple)
+		} else if bNaf[i] < 0 {
+			v.fromP1xP1(tmp1)
+			qTable.SelectInto(multiple, -bNaf[i])
+			tmp1.Sub(v, multiple)
+		}
+
+		if cNaf[i] > 0 {
+			v.fromP1xP1(tmp1)
+			bTable.SelectInto(multB, cNaf[i])
+			tmp1.AddAffine(v, multB)
+		} else if cNaf[i] < 0 {
+			v.fromP1xP1(tmp1)
+			bTable.SelectInto(multB, -cNaf[i])
+			tmp1.SubAffine(v, multB)
+		}
+
+		tmp2.FromP1xP1(tmp1)
+	}
+
+	v.fromP2(tmp2)
+	return v
+}
+
+// VarTimeMultiScalarMultWithTables sets v = sum(scalars[i] * P_i), where P_i
+// is the point whose odd multiples are in tables[i], and returns v.
+//
+// tables[i] must hold P_i, 3 * P_i, 5 * P_i, ..., in order, and its length
+// must be 2^(w-2) for some NAF width w between 2 and 8, which is used for the
+// corresponding scalar. Wider tables require fewer additions. This allows the
+// caller to build, store, and share tables with any strategy, and only
+// delegate the evaluation. The tables are not checked.
+//
+// The lengths of scalars and tables must match. Execution time depends on the
+// inputs.
+func (v *Point) VarTimeMultiScalarMultWithTables(scalars []*Scalar, tables [][]Point) *Point {
+	if len(scalars) != len(tables) {
+		panic("edwards25519: called VarTimeMultiScalarMultWithTables with different size inputs")
+	}
+	nafs := make([][256]int8, len(scalars))
+	for i, table := range tables {
+		w := uint(2)
+		for 1<<(w-2) < len(table) {
+			w++
+		}
+		if w > 8 || 1<<(w-2) != len(table) {
+			panic("edwards25519: invalid table length passed to VarTimeMultiScalarMultWithTables")
+		}
+		for j := range table {
+			checkInitialized(&table[j])
+		}
+		nafs[i] = scalars[i].nonAdjacentForm(w)
+	}
+
+	multiple := &projCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	tmp2.Zero()
+
+	for i := 255; i >= 0; i-- {
+		tmp1.Double(tmp2)
+
+		for j := range nafs {
+			if nafs[j][i] > 0 {
+				v.fromP1xP1(tmp1)
+				multiple.FromP3(&tables[j][nafs[j][i]/2])
+				tmp1.Add(v, multiple)
+			} else if nafs[j][i] < 0 {
+				v.fromP1xP1(tmp1)
+				multiple.FromP3(&tables[j][-nafs[j][i]/2])
+				tmp1.Sub(v, multiple)
+			}
+		}
+
 		tmp2.FromP1xP1(tmp1)
 	}
 
 	v.fromP2(tmp2)
 	return v
 }
+
+// VarTimeMultiScalarMultDedup works like VarTimeMultiScalarMult, but first
+// merges the scalars of repeated points, so that a single lookup table is built
+// for each distinct point. Points are repeated if they are the same pointer, or
+// if they have the same encoding.
+//
+// This is faster than VarTimeMultiScalarMult when the inputs contain few
+// distinct points, such as when batch verifying signatures from few keys.
+//
+// The scalars and points are not modified.
+func (v *Point) VarTimeMultiScalarMultDedup(scalars []*Scalar, points []*Point) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called VarTimeMultiScalarMultDedup with different size inputs")
+	}
+	checkInitialized(points...)
+
+	// First, merge repeated pointers, which is free.
+	var uniqScalars []*Scalar
+	var uniqPoints []*Point
+	byPointer := make(map[*Point]int, len(points))
+	for i, p := range points {
+		if j, ok := byPointer[p]; ok {
+			uniqScalars[j].Add(uniqScalars[j], scalars[i])
+			continue
+		}
+		byPointer[p] = len(uniqPoints)
+		uniqScalars = append(uniqScalars, new(Scalar).Set(scalars[i]))
+		uniqPoints = append(uniqPoints, p)
+	}
+
+	// Then, merge distinct pointers to equal points, by comparing encodings
+	// computed with a single shared field inversion.
+	n := len(uniqPoints)
+	zs := make([]field.Element, 2*n)
+	zInvs, scratch := zs[:n], zs[n:]
+	for i, p := range uniqPoints {
+		zInvs[i].Set(&p.z)
+	}
+	invertAll(zInvs, zInvs, scratch)
+
+	var mergedScalars []*Scalar
+	var mergedPoints []*Point
+	byEncoding := make(map[[32]byte]int, n)
+	for i, p := range uniqPoints {
+		var enc [32]byte
+		p.bytesWithZInv(&enc, &zInvs[i])
+		if j, ok := byEncoding[enc]; ok {
+			mergedScalars[j].Add(mergedScalars[j], uniqScalars[i])
+			continue
+		}
+		byEncoding[enc] = len(mergedPoints)
+		mergedScalars = append(mergedScalars, uniqScalars[i])
+		mergedPoints = append(mergedPoints, p)
+	}
+
+	return v.VarTimeMultiScalarMult(mergedScalars, mergedPoints)
+}
+
+// VarTimeMultiScalarMultIndexed sets v = sum(scalars[i] * points[indices[i]]),
+// and returns v. The lengths of scalars and indices must match, and every
+// index must be in the range [0, len(points)).
+//
+// It's the explicit form of VarTimeMultiScalarMultDedup, for callers that
+// already know which terms share a point, such as aggregated verification of
+// many signatures from a few keys. The scalars of each point are summed first,
+// so the cost depends on len(points) rather than on len(scalars).
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultIndexed(scalars []*Scalar, indices []int, points []*Point) *Point {
+	if len(scalars) != len(indices) {
+		panic("edwards25519: called VarTimeMultiScalarMultIndexed with different size inputs")
+	}
+	checkInitialized(points...)
+
+	merged := make([]Scalar, len(points))
+	for i, j := range indices {
+		if j < 0 || j >= len(points) {
+			panic("edwards25519: invalid index passed to VarTimeMultiScalarMultIndexed")
+		}
+		merged[j].Add(&merged[j], scalars[i])
+	}
+	mergedScalars := make([]*Scalar, len(points))
+	for i := range merged {
+		mergedScalars[i] = &merged[i]
+	}
+	return v.varTimeMultiScalarMult(&msmTerms{scalars: mergedScalars, points: points}, nil, nil)
+}
+
+// CheckedVarTimeMultiScalarMult works like VarTimeMultiScalarMult, but if the
+// slices have different lengths, or if any of the scalars or points is nil or
+// uninitialized, it returns nil and an error instead of panicking, and the
+// receiver is unchanged.
+func (v *Point) CheckedVarTimeMultiScalarMult(scalars []*Scalar, points []*Point) (*Point, error) {
+	if err := checkMultiScalarMultInputs(scalars, points); err != nil {
+		return nil, err
+	}
+	return v.VarTimeMultiScalarMult(scalars, points), nil
+}
+
+func checkMultiScalarMultInputs(scalars []*Scalar, points []*Point) error {
+	if len(scalars) != len(points) {
+		return errors.New("edwards25519: different number of scalars and points")
+	}
+	for _, s := range scalars {
+		if s == nil {
+			return errors.New("edwards25519: nil Scalar")
+		}
+	}
+	for _, p := range points {
+		if p == nil {
+			return errors.New("edwards25519: nil Point")
+		}
+		if p.x == (field.Element{}) && p.y == (field.Element{}) {
+			return errors.New("edwards25519: use of uninitialized Point")
+		}
+	}
+	return nil
+}
@@ -5,10 +5,16 @@
 package edwards25519
 
 import (
+	"bytes"
 	"crypto/rand"
+	"crypto/sha512"
+	"encoding/binary"
 	"encoding/hex"
+	"math/big"
 	"testing"
 	"testing/quick"
+
+	"filippo.io/edwards25519/field"
 )
 
 // TestBytesMontgomery tests the SetBytesWithClamping+BytesMontgomery path
@@ -56,17 +62,478 @@ func TestBytesMontgomeryInfinity(t *testing.T) {
 	}
 }
 
-func TestMultByCofactor(t *testing.T) {
-	lowOrderBytes := "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85"
-	lowOrder, err := (&Point{}).SetBytes(decodeHex(lowOrderBytes))
-	if err != nil {
-		t.Fatal(err)
+func TestDouble(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		var got, want Point
+		got.Double(p)
+		want.Add(p, p)
+		checkOnCurve(t, &got)
+		return got.Equal(&want) == 1 && p.Double(p).Equal(&want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	if p := new(Point).Double(I); p.Equal(I) != 1 {
+		t.Error("2 * identity != identity")
+	}
+}
+
+func TestMultByPow2(t *testing.T) {
+	f := func(x Scalar, k uint8) bool {
+		k %= 10
+		p := new(Point).ScalarBaseMult(&x)
+		want := new(Point).Set(p)
+		for i := uint8(0); i < k; i++ {
+			want.Add(want, want)
+		}
+		got := new(Point).MultByPow2(p, int(k))
+		checkOnCurve(t, got)
+		return got.Equal(want) == 1 && p.MultByPow2(p, int(k)).Equal(want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for negative exponent")
+		}
+	}()
+	new(Point).MultByPow2(B, -1)
+}
+
+func TestTriple(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		var got, want Point
+		got.Triple(p)
+		want.Add(p, p).Add(&want, p)
+		checkOnCurve(t, &got)
+		return got.Equal(&want) == 1 && p.Triple(p).Equal(&want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	if p := new(Point).Triple(I); p.Equal(I) != 1 {
+		t.Error("3 * identity != identity")
+	}
+}
+
+func TestIsValidYCoordinate(t *testing.T) {
+	f := func(y [32]byte) bool {
+		_, err := new(Point).SetBytes(y[:])
+		return IsValidYCoordinate(y[:]) == (err == nil)
+	}
+	if err := quick.Check(f, quickCheckConfig(256)); err != nil {
+		t.Error(err)
+	}
+
+	for _, y := range []string{
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"f0ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+	} {
+		if !IsValidYCoordinate(decodeHex(y)) {
+			t.Errorf("IsValidYCoordinate(%s) = false", y)
+		}
+	}
+	for _, y := range []string{
+		"efffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0200000000000000000000000000000000000000000000000000000000000000",
+		"ffff",
+	} {
+		if IsValidYCoordinate(decodeHex(y)) {
+			t.Errorf("IsValidYCoordinate(%s) = true", y)
+		}
+	}
+}
+
+func TestVarTimeValidateEncodings(t *testing.T) {
+	var encodings [][]byte
+	var want []error
+	for _, v := range EdgeCaseEncodings() {
+		enc := v.Encoding
+		encodings = append(encodings, enc[:])
+		switch {
+		case !v.Canonical:
+			want = append(want, ErrNonCanonicalEncoding)
+		case v.TorsionOrder != 1:
+			want = append(want, ErrNotTorsionFree)
+		default:
+			want = append(want, nil)
+		}
+	}
+	encodings = append(encodings, B.Bytes(), dalekScalarBasepoint.Bytes())
+	want = append(want, nil, nil)
+
+	errs := VarTimeValidateEncodings(encodings)
+	if len(errs) != len(encodings) {
+		t.Fatalf("got %d errors, want %d", len(errs), len(encodings))
+	}
+	for i, err := range errs {
+		if err != want[i] {
+			t.Errorf("encoding %x: got %v, want %v", encodings[i], err, want[i])
+		}
+	}
+
+	invalid := [][]byte{
+		decodeHex("0200000000000000000000000000000000000000000000000000000000000000"),
+		B.Bytes()[:31],
+		B.Bytes(),
+	}
+	if errs := VarTimeValidateEncodings(invalid); errs[0] == nil || errs[1] == nil || errs[2] != nil {
+		t.Errorf("invalid encodings: got %v", errs)
+	}
+
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		return VarTimeValidateEncodings([][]byte{p.Bytes(), B.Bytes()}) == nil
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAddPairwise(t *testing.T) {
+	f := func(x, y [4]Scalar) bool {
+		var a, b, out []*Point
+		for i := range x {
+			a = append(a, new(Point).ScalarBaseMult(&x[i]))
+			b = append(b, new(Point).ScalarBaseMult(&y[i]))
+			out = append(out, new(Point))
+		}
+		// out[3] aliases a[3].
+		out[3] = a[3]
+		want := new(Point).Add(a[3], b[3])
+
+		AddPairwise(out, a, b)
+		for i := 0; i < 3; i++ {
+			if out[i].Equal(new(Point).Add(a[i], b[i])) != 1 {
+				return false
+			}
+		}
+		return out[3].Equal(want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("mismatched lengths did not panic")
+		}
+	}()
+	AddPairwise([]*Point{new(Point)}, []*Point{B}, nil)
+}
+
+func TestEqualModTorsion(t *testing.T) {
+	f := func(x, y Scalar, i uint8) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		q := new(Point).ScalarBaseMult(&y)
+		pt := new(Point).Add(p, SmallOrderPoints()[i%8])
+
+		want := new(Point).MultByCofactor(p).Equal(new(Point).MultByCofactor(q))
+		return p.EqualModTorsion(q) == want &&
+			p.EqualModTorsion(pt) == 1 && pt.EqualModTorsion(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	if B.EqualModTorsion(dalekScalarBasepoint) != 0 {
+		t.Error("B and dalekScalarBasepoint are equal modulo torsion")
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	h := sha512.Sum512(append([]byte("filippo.io/edwards25519 point fingerprint v1"), B.Bytes()...))
+	for _, n := range []int{1, 16, 64} {
+		if got := B.Fingerprint(n); !bytes.Equal(got, h[:n]) {
+			t.Errorf("Fingerprint(%d) = %x, expected %x", n, got, h[:n])
+		}
+	}
+
+	// Equivalent points with different representations have the same
+	// fingerprint, and different points don't.
+	BB := new(Point).Add(B, I)
+	if !bytes.Equal(B.Fingerprint(16), BB.Fingerprint(16)) {
+		t.Error("equivalent points have different fingerprints")
+	}
+	if bytes.Equal(B.Fingerprint(16), dalekScalarBasepoint.Fingerprint(16)) {
+		t.Error("different points have the same fingerprint")
+	}
+
+	for _, n := range []int{0, -1, 65} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Fingerprint(%d) did not panic", n)
+				}
+			}()
+			B.Fingerprint(n)
+		}()
+	}
+}
+
+func TestReset(t *testing.T) {
+	p := new(Point).Set(dalekScalarBasepoint)
+	p.Reset()
+	checkOnCurve(t, p)
+	if p.Equal(I) != 1 {
+		t.Error("Reset Point is not the identity")
+	}
+
+	s := new(Scalar).Set(dalekScalar)
+	s.Reset()
+	if s.Equal(NewScalar()) != 1 || *s != (Scalar{}) {
+		t.Error("Reset Scalar is not zero")
+	}
+}
+
+func TestAffineBytes(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		b := p.AffineBytes()
+		if len(b) != 64 {
+			return false
+		}
+
+		zInv := new(field.Element).Invert(&p.z)
+		wantX := new(field.Element).Multiply(&p.x, zInv)
+		wantY := new(field.Element).Multiply(&p.y, zInv)
+		if !bytes.Equal(b[:32], wantX.Bytes()) || !bytes.Equal(b[32:], wantY.Bytes()) {
+			return false
+		}
+
+		q, err := new(Point).SetAffineBytes(b)
+		if err != nil {
+			return false
+		}
+		checkOnCurve(t, q)
+		return q.Equal(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	b := B.AffineBytes()
+	if !bytes.Equal(b[32:], B.Bytes()) {
+		t.Error("the y coordinate of B does not match its encoding")
+	}
+
+	for name, bad := range map[string][]byte{
+		"short": b[:63],
+		// x + p is not canonical.
+		"non-canonical x": func() []byte {
+			b := new(Point).Set(I).AffineBytes()
+			copy(b[:32], decodeHex("edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"))
+			return b
+		}(),
+		// (0, 2) is not on the curve.
+		"off curve": append(make([]byte, 32), append([]byte{2}, make([]byte, 31)...)...),
+	} {
+		p := new(Point).Set(B)
+		if out, err := p.SetAffineBytes(bad); err == nil || out != nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if p.Equal(B) != 1 {
+			t.Errorf("%s: receiver was modified", name)
+		}
+	}
+}
+
+func TestEqualVarTime(t *testing.T) {
+	f := func(x, y Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		q := new(Point).ScalarBaseMult(&y)
+		// Same point with a different Z coordinate.
+		p2 := new(Point).Add(p, I)
+		return p.EqualVarTime(q) == p.Equal(q) &&
+			p.EqualVarTime(p2) == 1 && p.EqualVarTime(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	// B and -B share the y coordinate but not the x coordinate.
+	negB := new(Point).Negate(B)
+	negB.Negate(negB)
+	if B.EqualVarTime(new(Point).Negate(B)) != 0 || B.EqualVarTime(negB) != 1 {
+		t.Error("EqualVarTime mismatch for B and -B")
+	}
+}
+
+func TestEqualEncodings(t *testing.T) {
+	if eq, err := EqualEncodings(B.Bytes(), dalekScalarBasepoint.Bytes()); err != nil || eq != 0 {
+		t.Errorf("EqualEncodings(B, dalek) = %d, %v", eq, err)
+	}
+	if eq, err := EqualEncodings(B.Bytes(), B.Bytes()); err != nil || eq != 1 {
+		t.Errorf("EqualEncodings(B, B) = %d, %v", eq, err)
+	}
+	invalid := decodeHex("efffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	if _, err := EqualEncodings(B.Bytes(), invalid); err == nil {
+		t.Error("expected error for invalid encoding")
+	}
+	if _, err := EqualEncodings(invalid, B.Bytes()); err == nil {
+		t.Error("expected error for invalid encoding")
 	}
+}
 
-	if p := (&Point{}).MultByCofactor(lowOrder); p.Equal(NewIdentityPoint()) != 1 {
-		t.Errorf("expected low order point * cofactor to be the identity")
+func TestScalarBaseMultWithDoublings(t *testing.T) {
+	f := func(x Scalar, mask uint8) bool {
+		doublings := make([]*Point, 8)
+		for i := range doublings {
+			if mask&(1<<i) != 0 {
+				doublings[i] = new(Point)
+			}
+		}
+		v := new(Point).ScalarBaseMultWithDoublings(&x, doublings)
+		want := new(Point).ScalarBaseMult(&x)
+		if v.Equal(want) != 1 {
+			return false
+		}
+		for _, d := range doublings {
+			want.Add(want, want)
+			if d == nil {
+				continue
+			}
+			checkOnCurve(t, d)
+			if d.Equal(want) != 1 {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
 	}
 
+	// The receiver may alias an output.
+	v := new(Point)
+	v.ScalarBaseMultWithDoublings(dalekScalar, []*Point{v, v})
+	if v.Equal(dalekScalarBasepoint) != 1 {
+		t.Error("aliased receiver was overwritten")
+	}
+}
+
+func TestTorsionComponent(t *testing.T) {
+	eight, _ := new(Scalar).SetCanonicalBytes([]byte{8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	if new(Scalar).Multiply(scalarInverseEight, eight).Equal(scOne) != 1 {
+		t.Fatal("scalarInverseEight is not the inverse of eight")
+	}
+
+	for _, v := range EdgeCaseEncodings() {
+		p, _ := new(Point).SetBytes(v.Encoding[:])
+		tc := new(Point).TorsionComponent(p)
+		checkOnCurve(t, tc)
+		if torsionOrder(tc) != v.TorsionOrder {
+			t.Errorf("%s: torsion component has order %d, expected %d", v.Name, torsionOrder(tc), v.TorsionOrder)
+		}
+		if new(Point).MultByCofactor(tc).Equal(I) != 1 {
+			t.Errorf("%s: torsion component is not of small order", v.Name)
+		}
+		if v.SmallOrder && tc.Equal(p) != 1 {
+			t.Errorf("%s: torsion component of small order point is not the point itself", v.Name)
+		}
+		a := new(Point).Subtract(p, tc)
+		if torsionOrder(a) != 1 {
+			t.Errorf("%s: p - torsion component is not torsion-free", v.Name)
+		}
+	}
+
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		return p.TorsionComponent(p).Equal(I) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarMultBytes(t *testing.T) {
+	f := func(x [32]byte, y Scalar, i uint8) bool {
+		// q = y * B + T, where T is a small order point.
+		T := SmallOrderPoints()[i%8]
+		yB := new(Point).ScalarBaseMult(&y)
+		q := new(Point).Add(yB, T)
+
+		// x * q = (x mod l) * yB + (x mod 8) * T
+		var wide [64]byte
+		copy(wide[:], x[:])
+		xModL := new(Scalar).SetWideBytes(&wide)
+		want := new(Point).ScalarMult(xModL, yB)
+		for j := byte(0); j < x[0]&7; j++ {
+			want.Add(want, T)
+		}
+
+		got, err := new(Point).ScalarMultBytes(x[:], q)
+		if err != nil {
+			return false
+		}
+		checkOnCurve(t, got)
+		return got.Equal(want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	// All ones exercises the top carry digit.
+	allOnes := bytes.Repeat([]byte{0xff}, 32)
+	if p, err := new(Point).ScalarMultBytes(allOnes, I); err != nil || p.Equal(I) != 1 {
+		t.Error("x * identity != identity")
+	}
+	var wide [64]byte
+	copy(wide[:], allOnes)
+	want := new(Point).ScalarBaseMult(new(Scalar).SetWideBytes(&wide))
+	if p, err := new(Point).ScalarMultBytes(allOnes, B); err != nil || p.Equal(want) != 1 {
+		t.Error("(2^256 - 1) * B is incorrect")
+	}
+	if _, err := new(Point).ScalarMultBytes(allOnes[:31], B); err == nil {
+		t.Error("expected error for short input")
+	}
+}
+
+func TestMultByCofactorInverse(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		q := new(Point).MultByCofactorInverse(p)
+		checkOnCurve(t, q)
+		return new(Point).MultByCofactor(q).Equal(p) == 1 &&
+			q.MultByCofactor(p).MultByCofactorInverse(q).Equal(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrimeOrderComponent(t *testing.T) {
+	f := func(x Scalar, i uint8) bool {
+		torsion := EdgeCaseEncodings()[i%8]
+		tt, _ := new(Point).SetBytes(torsion.Encoding[:])
+		xB := new(Point).ScalarBaseMult(&x)
+		p := new(Point).Add(xB, tt)
+
+		a := new(Point).PrimeOrderComponent(p)
+		checkOnCurve(t, a)
+		return a.Equal(xB) == 1 && p.PrimeOrderComponent(p).Equal(xB) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultByCofactor(t *testing.T) {
+	for _, lowOrder := range SmallOrderPoints() {
+		if p := (&Point{}).MultByCofactor(lowOrder); p.Equal(NewIdentityPoint()) != 1 {
+			t.Errorf("expected low order point * cofactor to be the identity")
+		}
+	}
+	lowOrder := SmallOrderPoints()[5]
+
 	f := func(scalar [64]byte) bool {
 		s, _ := NewScalar().SetUniformBytes(scalar[:])
 		p := (&Point{}).ScalarBaseMult(s)
@@ -101,6 +568,68 @@ func TestMultByCofactor(t *testing.T) {
 	}
 }
 
+func TestScalarMultWithClamping(t *testing.T) {
+	lowOrder, err := (&Point{}).SetBytes(decodeHex("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := func(x [32]byte) bool {
+		s, _ := new(Scalar).SetBytesWithClamping(x[:])
+		want := new(Point).ScalarBaseMult(s)
+
+		var got Point
+		if out, err := got.ScalarMultWithClamping(x[:], B); err != nil || out != &got {
+			return false
+		}
+		if got.Equal(want) != 1 {
+			return false
+		}
+
+		// The cofactor-clearing property is preserved.
+		mixed := new(Point).Add(B, lowOrder)
+		got.ScalarMultWithClamping(x[:], mixed)
+		if got.Equal(want) != 1 {
+			return false
+		}
+		got.ScalarMultWithClamping(x[:], lowOrder)
+		checkOnCurve(t, &got)
+		return got.Equal(I) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	// Generated with libsodium.js 1.0.18 crypto_scalarmult_ed25519_base.
+	random := "633d368491364dc9cd4c1bf891b1d59460face1644813240a313e61f2c88216e"
+	p, _ := new(Point).ScalarMultWithClamping(decodeHex(random), B)
+	want := "1d87a9026fd0126a5736fe1628c95dd419172b5b618457e041c9c861b2494a94"
+	if got := hex.EncodeToString(p.Bytes()); got != want {
+		t.Errorf("random: got %q, want %q", got, want)
+	}
+
+	if out, err := p.ScalarMultWithClamping(make([]byte, 31), B); err == nil || out != nil {
+		t.Error("expected error for short input")
+	}
+}
+
+func BenchmarkScalarMultWithClamping(b *testing.B) {
+	x := decodeHex("633d368491364dc9cd4c1bf891b1d59460face1644813240a313e61f2c88216e")
+	var p Point
+	b.Run("ScalarMultWithClamping", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.ScalarMultWithClamping(x, dalekScalarBasepoint)
+		}
+	})
+	b.Run("SetBytesWithClamping+ScalarMult", func(b *testing.B) {
+		var s Scalar
+		for i := 0; i < b.N; i++ {
+			s.SetBytesWithClamping(x)
+			p.ScalarMult(&s, dalekScalarBasepoint)
+		}
+	})
+}
+
 func TestScalarInvert(t *testing.T) {
 	invertWorks := func(xInv Scalar, x notZeroScalar) bool {
 		xInv.Invert((*Scalar)(&x))
@@ -129,6 +658,47 @@ func TestScalarInvert(t *testing.T) {
 	}
 }
 
+func TestScalarInvertSafegcd(t *testing.T) {
+	f := func(x Scalar) bool {
+		var got, want Scalar
+		got.invertSafegcd(&x)
+		want.invertChain(&x)
+		if got.Equal(&want) != 1 {
+			t.Logf("got: %x,\nexpected: %x", got.Bytes(), want.Bytes())
+		}
+		return got.Equal(&want) == 1 && isReduced(got.Bytes()) &&
+			x.invertSafegcd(&x) == &x && x.Equal(&got) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	for _, x := range []*Scalar{NewScalar(), scOne, scMinusOne, dalekScalar} {
+		if !f(*x) {
+			t.Errorf("failed for %x", x.Bytes())
+		}
+	}
+}
+
+func TestScalarInvertVarTime(t *testing.T) {
+	f := func(x Scalar) bool {
+		var got, want Scalar
+		got.InvertVarTime(&x)
+		want.Invert(&x)
+		return got.Equal(&want) == 1 && isReduced(got.Bytes()) &&
+			x.InvertVarTime(&x) == &x && x.Equal(&got) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	for _, x := range []*Scalar{NewScalar(), scOne, scMinusOne, dalekScalar} {
+		if !f(*x) {
+			t.Errorf("failed for %x", x.Bytes())
+		}
+	}
+}
+
 func TestMultiScalarMultMatchesBaseMult(t *testing.T) {
 	multiScalarMultMatchesBaseMult := func(x, y, z Scalar) bool {
 		var p, q1, q2, q3, check Point
@@ -169,6 +739,384 @@ func TestVarTimeMultiScalarMultMatchesBaseMult(t *testing.T) {
 	}
 }
 
+func TestVarTimeMultiScalarMultShortScalars(t *testing.T) {
+	short := func(x uint64) *Scalar {
+		var b [32]byte
+		binary.LittleEndian.PutUint64(b[:], x)
+		s, _ := new(Scalar).SetCanonicalBytes(b[:])
+		return s
+	}
+	f := func(x uint8, y uint16, z uint32) bool {
+		scalars := []*Scalar{short(uint64(x)), short(uint64(y)), short(uint64(z)), short(8)}
+		_, points := msmTestInputs(len(scalars))
+
+		var p, q, r, check Point
+		p.VarTimeMultiScalarMult(scalars, points)
+		q.varTimePippenger(&msmTerms{scalars: scalars, points: points}, scalars[0], 5, nil)
+		r.VarTimeDoubleScalarBaseMult(scalars[1], points[1], scalars[2])
+		check.MultiScalarMult(scalars, points)
+		checkOnCurve(t, &p, &q, &r)
+
+		var qCheck, rCheck Point
+		qCheck.ScalarBaseMult(scalars[0]).Add(&qCheck, &check)
+		rCheck.DoubleScalarMult(scalars[1], points[1], scalars[2], B)
+		return p.Equal(&check) == 1 && q.Equal(&qCheck) == 1 && r.Equal(&rCheck) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	// All zero scalars skip every doubling.
+	zeros := []*Scalar{NewScalar(), NewScalar()}
+	var p Point
+	if p.VarTimeMultiScalarBaseMult(zeros, []*Point{B, B}, NewScalar()).Equal(I) != 1 {
+		t.Error("zero scalars did not produce the identity")
+	}
+	if p.varTimePippenger(&msmTerms{scalars: zeros, points: []*Point{B, B}}, nil, 5, nil).Equal(I) != 1 {
+		t.Error("zero scalars did not produce the identity with Pippenger")
+	}
+	if p.VarTimeDoubleScalarBaseMult(NewScalar(), B, NewScalar()).Equal(I) != 1 {
+		t.Error("zero scalars did not produce the identity with VarTimeDoubleScalarBaseMult")
+	}
+}
+
+func TestVarTimeScalarMult(t *testing.T) {
+	f := func(x, y Scalar) bool {
+		q := new(Point).ScalarBaseMult(&y)
+		var p, check Point
+		p.VarTimeScalarMult(&x, q)
+		check.ScalarMult(&x, q)
+
+		// The receiver may alias the input point.
+		q.VarTimeScalarMult(&x, q)
+
+		checkOnCurve(t, &p, &check, q)
+		return p.Equal(&check) == 1 && q.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	if p.VarTimeScalarMult(NewScalar(), B).Equal(I) != 1 {
+		t.Error("0 * B != identity")
+	}
+	if p.VarTimeScalarMult(dalekScalar, B).Equal(dalekScalarBasepoint) != 1 {
+		t.Error("dalekScalar * B != dalekScalarBasepoint")
+	}
+}
+
+func BenchmarkVarTimeScalarMult(b *testing.B) {
+	var p Point
+	for i := 0; i < b.N; i++ {
+		p.VarTimeScalarMult(dalekScalar, B)
+	}
+}
+
+func TestVarTimeScalarBaseMult(t *testing.T) {
+	f := func(x Scalar) bool {
+		var p, check Point
+		p.VarTimeScalarBaseMult(&x)
+		check.ScalarBaseMult(&x)
+		checkOnCurve(t, &p, &check)
+		return p.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	if p.VarTimeScalarBaseMult(NewScalar()).Equal(I) != 1 {
+		t.Error("0 * B != identity")
+	}
+	if p.VarTimeScalarBaseMult(dalekScalar).Equal(dalekScalarBasepoint) != 1 {
+		t.Error("dalekScalar * B != dalekScalarBasepoint")
+	}
+}
+
+func BenchmarkVarTimeScalarBaseMult(b *testing.B) {
+	var p Point
+	for i := 0; i < b.N; i++ {
+		p.VarTimeScalarBaseMult(dalekScalar)
+	}
+}
+
+func TestVarTimeMultiScalarBaseMult(t *testing.T) {
+	f := func(x, y, z Scalar) bool {
+		q := new(Point).ScalarBaseMult(dalekScalar)
+		var p, check Point
+		p.VarTimeMultiScalarBaseMult([]*Scalar{&x, &y}, []*Point{q, B}, &z)
+		check.VarTimeMultiScalarMult([]*Scalar{&x, &y, &z}, []*Point{q, B, B})
+		checkOnCurve(t, &p, &check)
+		return p.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	p.VarTimeMultiScalarBaseMult(nil, nil, dalekScalar)
+	if p.Equal(dalekScalarBasepoint) != 1 {
+		t.Error("VarTimeMultiScalarBaseMult with no dynamic points != b * B")
+	}
+}
+
+func BenchmarkVarTimeMultiScalarBaseMultSize8(b *testing.B) {
+	var p Point
+	x := dalekScalar
+	for i := 0; i < b.N; i++ {
+		p.VarTimeMultiScalarBaseMult([]*Scalar{x, x, x, x, x, x, x},
+			[]*Point{B, B, B, B, B, B, B}, x)
+	}
+}
+
+func BenchmarkVarTimeMultiScalarMultShortSize8(b *testing.B) {
+	var p Point
+	x, _ := new(Scalar).SetCanonicalBytes(append([]byte{8}, make([]byte, 31)...))
+	for i := 0; i < b.N; i++ {
+		p.VarTimeMultiScalarMult([]*Scalar{x, x, x, x, x, x, x, x},
+			[]*Point{B, B, B, B, B, B, B, B})
+	}
+}
+
+func TestVarTimeTripleScalarBaseMult(t *testing.T) {
+	f := func(x, y, z Scalar) bool {
+		P := new(Point).ScalarBaseMult(dalekScalar)
+		Q := new(Point).ScalarBaseMult(&z)
+		var p, check Point
+		p.VarTimeTripleScalarBaseMult(&x, P, &y, Q, &z)
+		check.VarTimeMultiScalarBaseMult([]*Scalar{&x, &y}, []*Point{P, Q}, &z)
+
+		// The receiver may alias the inputs.
+		Q.VarTimeTripleScalarBaseMult(&x, P, &y, Q, &z)
+
+		checkOnCurve(t, &p, &check, Q)
+		return p.Equal(&check) == 1 && Q.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	zero := NewScalar()
+	if p.VarTimeTripleScalarBaseMult(zero, B, zero, B, zero).Equal(I) != 1 {
+		t.Error("0 * B + 0 * B + 0 * B != identity")
+	}
+}
+
+func BenchmarkVarTimeTripleScalarBaseMult(b *testing.B) {
+	var p Point
+	x := dalekScalar
+	for i := 0; i < b.N; i++ {
+		p.VarTimeTripleScalarBaseMult(x, B, x, dalekScalarBasepoint, x)
+	}
+}
+
+// oddMultiples returns P, 3 * P, ..., (2n - 1) * P.
+func oddMultiples(p *Point, n int) []Point {
+	table := make([]Point, n)
+	if n == 0 {
+		return table
+	}
+	p2 := new(Point).Add(p, p)
+	table[0].Set(p)
+	for i := 1; i < n; i++ {
+		table[i].Add(&table[i-1], p2)
+	}
+	return table
+}
+
+func TestVarTimeMultiScalarMultWithTables(t *testing.T) {
+	f := func(x, y, z Scalar) bool {
+		q := new(Point).ScalarBaseMult(dalekScalar)
+		tables := [][]Point{oddMultiples(B, 1), oddMultiples(q, 8), oddMultiples(B, 64)}
+
+		var p, check Point
+		p.VarTimeMultiScalarMultWithTables([]*Scalar{&x, &y, &z}, tables)
+		check.VarTimeMultiScalarMult([]*Scalar{&x, &y, &z}, []*Point{B, q, B})
+		checkOnCurve(t, &p, &check)
+		return p.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	for _, n := range []int{0, 3, 128} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("table of length %d did not panic", n)
+				}
+			}()
+			new(Point).VarTimeMultiScalarMultWithTables([]*Scalar{scOne}, [][]Point{oddMultiples(B, n)})
+		}()
+	}
+}
+
+func TestVarTimeMultiScalarMultDedup(t *testing.T) {
+	f := func(x, y, z, w Scalar) bool {
+		var p, check Point
+
+		// B2 is a different pointer to a point equal to B.
+		B2 := new(Point).Set(B)
+		q := new(Point).ScalarBaseMult(dalekScalar)
+		scalars := []*Scalar{&x, &y, &z, &w}
+		points := []*Point{B, q, B2, B}
+		xx := x
+
+		p.VarTimeMultiScalarMultDedup(scalars, points)
+		check.VarTimeMultiScalarMult(scalars, points)
+
+		checkOnCurve(t, &p, &check)
+		return p.Equal(&check) == 1 && x == xx
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	p.VarTimeMultiScalarMultDedup(nil, nil)
+	if p.Equal(I) != 1 {
+		t.Error("empty multiscalar multiplication is not the identity")
+	}
+}
+
+func TestVarTimeMultiScalarMultIndexed(t *testing.T) {
+	f := func(x, y, z, w Scalar) bool {
+		q := new(Point).ScalarBaseMult(dalekScalar)
+		points := []*Point{B, q, dalekScalarBasepoint}
+		scalars := []*Scalar{&x, &y, &z, &w}
+		indices := []int{0, 1, 0, 1}
+		xx := x
+
+		var p, check Point
+		p.VarTimeMultiScalarMultIndexed(scalars, indices, points)
+		check.VarTimeMultiScalarMult(scalars, []*Point{B, q, B, q})
+		checkOnCurve(t, &p, &check)
+		return p.Equal(&check) == 1 && x == xx
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	p.VarTimeMultiScalarMultIndexed(nil, nil, []*Point{B})
+	if p.Equal(I) != 1 {
+		t.Error("empty multiscalar multiplication is not the identity")
+	}
+
+	for _, index := range []int{-1, 1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("index %d did not panic", index)
+				}
+			}()
+			p.VarTimeMultiScalarMultIndexed([]*Scalar{scOne}, []int{index}, []*Point{B})
+		}()
+	}
+}
+
+func TestMultiScalarMultValues(t *testing.T) {
+	for _, n := range []int{0, 1, 5, pippengerThreshold} {
+		scalars, points := msmTestInputs(n)
+		scalarValues, pointValues := make([]Scalar, n), make([]Point, n)
+		for i := range scalars {
+			scalarValues[i].Set(scalars[i])
+			pointValues[i].Set(points[i])
+		}
+
+		var p, q, check Point
+		check.VarTimeMultiScalarMult(scalars, points)
+		q.VarTimeMultiScalarMultValues(scalarValues, pointValues)
+		if q.Equal(&check) != 1 {
+			t.Errorf("n = %d: VarTimeMultiScalarMultValues does not match", n)
+		}
+		if n > 5 && testing.Short() {
+			continue
+		}
+		p.MultiScalarMultValues(scalarValues, pointValues)
+		if p.Equal(&check) != 1 {
+			t.Errorf("n = %d: MultiScalarMultValues does not match", n)
+		}
+	}
+}
+
+func TestVarTimeMultiScalarMultEncoded(t *testing.T) {
+	scalars, points := msmTestInputs(5)
+	encodings := make([][]byte, len(points))
+	for i, p := range points {
+		encodings[i] = p.Bytes()
+	}
+
+	var p, check Point
+	if _, errs := p.VarTimeMultiScalarMultEncoded(scalars, encodings); errs != nil {
+		t.Fatalf("valid encodings returned errors: %v", errs)
+	}
+	check.VarTimeMultiScalarMult(scalars, points)
+	checkOnCurve(t, &p)
+	if p.Equal(&check) != 1 {
+		t.Error("VarTimeMultiScalarMultEncoded does not match VarTimeMultiScalarMult")
+	}
+
+	// y = 2 is not on the curve.
+	invalid := make([]byte, 32)
+	invalid[0] = 2
+	encodings[1], encodings[3] = invalid, invalid[:31]
+	p.Set(B)
+	out, errs := p.VarTimeMultiScalarMultEncoded(scalars, encodings)
+	if out != nil || len(errs) != len(encodings) {
+		t.Fatalf("invalid encodings returned %v, %v", out, errs)
+	}
+	for i, err := range errs {
+		if (err != nil) != (i == 1 || i == 3) {
+			t.Errorf("encoding %d: unexpected error %v", i, err)
+		}
+	}
+	if p.Equal(B) != 1 {
+		t.Error("receiver was modified on error")
+	}
+}
+
+func TestMultiScalarMultReceiver(t *testing.T) {
+	// The initial value of the receiver must not affect the result.
+	v := new(Point).Set(dalekScalarBasepoint)
+	v.MultiScalarMult([]*Scalar{scOne}, []*Point{B})
+	if v.Equal(B) != 1 {
+		t.Error("MultiScalarMult depends on the receiver value")
+	}
+	v.DoubleScalarMult(scOne, B, NewScalar(), B)
+	if v.Equal(B) != 1 {
+		t.Error("DoubleScalarMult depends on the receiver value")
+	}
+}
+
+func TestDoubleScalarMult(t *testing.T) {
+	f := func(x, y, z Scalar) bool {
+		A := new(Point).ScalarBaseMult(&z)
+		var p, check Point
+		p.DoubleScalarMult(&x, A, &y, B)
+		check.VarTimeDoubleScalarBaseMult(&x, A, &y)
+
+		// The receiver may alias the inputs.
+		q := new(Point).Set(A)
+		q.DoubleScalarMult(&x, q, &y, B)
+
+		checkOnCurve(t, &p, &check, q)
+		return p.Equal(&check) == 1 && q.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkDoubleScalarMult(b *testing.B) {
+	var p Point
+	for i := 0; i < b.N; i++ {
+		p.DoubleScalarMult(dalekScalar, B, dalekScalar, dalekScalarBasepoint)
+	}
+}
+
 func BenchmarkMultiScalarMultSize8(t *testing.B) {
 	var p Point
 	x := dalekScalar
@@ -218,3 +1166,168 @@ func BenchmarkScalarInversion(b *testing.B) {
 		s1.Invert(s1)
 	}
 }
+
+func BenchmarkScalarInversionVarTime(b *testing.B) {
+	var rnd [64]byte
+	rand.Read(rnd[:])
+	s1, _ := (&Scalar{}).SetUniformBytes(rnd[0:64])
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		s1.InvertVarTime(s1)
+	}
+}
+
+func TestCheckedMultiScalarMult(t *testing.T) {
+	for name, f := range map[string]func(v *Point, s []*Scalar, p []*Point) (*Point, error){
+		"CheckedMultiScalarMult":        (*Point).CheckedMultiScalarMult,
+		"CheckedVarTimeMultiScalarMult": (*Point).CheckedVarTimeMultiScalarMult,
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, tt := range []struct {
+				name    string
+				scalars []*Scalar
+				points  []*Point
+			}{
+				{"length mismatch", []*Scalar{dalekScalar, dalekScalar}, []*Point{B}},
+				{"uninitialized point", []*Scalar{dalekScalar, dalekScalar}, []*Point{B, {}}},
+				{"nil point", []*Scalar{dalekScalar}, []*Point{nil}},
+				{"nil scalar", []*Scalar{nil}, []*Point{B}},
+			} {
+				v := NewGeneratorPoint()
+				if out, err := f(v, tt.scalars, tt.points); err == nil || out != nil {
+					t.Errorf("%s: expected error", tt.name)
+				}
+				if v.Equal(B) != 1 {
+					t.Errorf("%s: receiver was modified", tt.name)
+				}
+			}
+
+			v := NewIdentityPoint()
+			out, err := f(v, []*Scalar{dalekScalar}, []*Point{B})
+			if err != nil || out != v {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if v.Equal(dalekScalarBasepoint) != 1 {
+				t.Error("wrong result")
+			}
+		})
+	}
+}
+
+func TestAppendBinary(t *testing.T) {
+	prefix := []byte("prefix")
+	p := dalekScalarBasepoint
+	buf := append(make([]byte, 0, 64), prefix...)
+	pOut, err := p.AppendBinary(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(pOut, append(prefix, p.Bytes()...)) {
+		t.Errorf("Point.AppendBinary = %x", pOut)
+	}
+	sOut, err := dalekScalar.AppendBinary(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sOut, append(prefix, dalekScalar.Bytes()...)) {
+		t.Errorf("Scalar.AppendBinary = %x", sOut)
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = p.AppendBinary(buf[:0])
+		buf, _ = dalekScalar.AppendBinary(buf[:0])
+	}); allocs > 0 {
+		t.Errorf("expected zero allocations, got %0.1v", allocs)
+	}
+}
+
+func BenchmarkDouble(b *testing.B) {
+	p := new(Point).Set(dalekScalarBasepoint)
+	for i := 0; i < b.N; i++ {
+		p.Double(p)
+	}
+}
+
+func BenchmarkTriple(b *testing.B) {
+	p := new(Point).Set(dalekScalarBasepoint)
+	for i := 0; i < b.N; i++ {
+		p.Triple(p)
+	}
+}
+
+func TestScalarSetFieldElement(t *testing.T) {
+	l := bigIntFromLittleEndianBytes(scalarMinusOneBytes[:])
+	l.Add(l, big.NewInt(1))
+
+	f := func(b [32]byte) bool {
+		x, _ := new(field.Element).SetBytes(b[:])
+		want := bigIntFromLittleEndianBytes(x.Bytes())
+		want.Mod(want, l)
+		got := new(Scalar).SetFieldElement(x)
+		return bigIntFromLittleEndianBytes(got.Bytes()).Cmp(want) == 0
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	// p - 1 is the largest canonical field element, and reduces to
+	// p - 1 - 7 * l.
+	pMinusOne := new(field.Element).Negate(new(field.Element).One())
+	want := bigIntFromLittleEndianBytes(pMinusOne.Bytes())
+	want.Sub(want, new(big.Int).Mul(l, big.NewInt(7)))
+	got := new(Scalar).SetFieldElement(pMinusOne)
+	if bigIntFromLittleEndianBytes(got.Bytes()).Cmp(want) != 0 {
+		t.Errorf("p - 1 reduced to %x", got.Bytes())
+	}
+}
+
+func TestPointValidate(t *testing.T) {
+	if err := dalekScalarBasepoint.Validate(true); err != nil {
+		t.Errorf("dalekScalarBasepoint: %v", err)
+	}
+	if err := I.Validate(true); err != nil {
+		t.Errorf("identity: %v", err)
+	}
+
+	mixed := new(Point).Add(B, SmallOrderPoints()[6])
+	if err := mixed.Validate(false); err != nil {
+		t.Errorf("mixed order point: %v", err)
+	}
+	if err := mixed.Validate(true); err != ErrNotTorsionFree {
+		t.Errorf("mixed order point: got %v, expected ErrNotTorsionFree", err)
+	}
+
+	if err := new(Point).Validate(false); err != ErrNotOnCurve {
+		t.Errorf("zero value: got %v, expected ErrNotOnCurve", err)
+	}
+	bad := new(Point).Set(B)
+	bad.t.Add(&bad.t, feOne)
+	if err := bad.Validate(false); err != ErrNotOnCurve {
+		t.Errorf("inconsistent T: got %v, expected ErrNotOnCurve", err)
+	}
+	bad.Set(B)
+	bad.x.Add(&bad.x, feOne)
+	if err := bad.Validate(true); err != ErrNotOnCurve {
+		t.Errorf("off-curve point: got %v, expected ErrNotOnCurve", err)
+	}
+}
+
+func TestXSignBit(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		if p.XSignBit() != int(p.Bytes()[31]>>7) {
+			return false
+		}
+		// Negation flips the sign of x, unless x is zero.
+		neg := new(Point).Negate(p)
+		return p.Equal(neg) == 1 || neg.XSignBit() == 1-p.XSignBit()
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+	if I.XSignBit() != 0 {
+		t.Error("identity has a negative x coordinate")
+	}
+}
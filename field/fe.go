@@ -6,7 +6,6 @@
 package field
 
 import (
-	"crypto/subtle"
 	"encoding/binary"
 	"errors"
 	"math/bits"
@@ -24,7 +23,9 @@ type Element struct {
 	// An element t represents the integer
 	//     t.l0 + t.l1*2^51 + t.l2*2^102 + t.l3*2^153 + t.l4*2^204
 	//
-	// Between operations, all limbs are expected to be lower than 2^52.
+	// Between operations, all limbs are expected to be lower than 2^52,
+	// except for the results of AddNoCarry and SubtractNoCarry, whose limbs
+	// are lower than 2^53.
 	l0 uint64
 	l1 uint64
 	l2 uint64
@@ -37,6 +38,10 @@ const maskLow51Bits uint64 = (1 << 51) - 1
 var feZero = &Element{0, 0, 0, 0, 0}
 
 // Zero sets v = 0, and returns v.
+//
+// Zero overwrites all the limbs of v, so it can be used to scrub secret
+// values once they are no longer needed. Note that it can't reach copies made
+// by the caller or by the Go runtime, for example when growing a stack.
 func (v *Element) Zero() *Element {
 	*v = *feZero
 	return v
@@ -118,6 +123,12 @@ func (v *Element) Negate(a *Element) *Element {
 //
 // If z == 0, Invert returns v = 0.
 func (v *Element) Invert(z *Element) *Element {
+	return v.invertSafegcd(z)
+}
+
+// invertFermat works like Invert, but is slower. It's used to test
+// invertSafegcd.
+func (v *Element) invertFermat(z *Element) *Element {
 	// Inversion is implemented as exponentiation with exponent p − 2. It uses the
 	// same sequence of 255 squarings and 11 multiplications as [Curve25519].
 	var z2, z9, z11, z2_5_0, z2_10_0, z2_20_0, z2_50_0, z2_100_0, t Element
@@ -232,26 +243,24 @@ func (v *Element) bytes(out *[32]byte) []byte {
 	t := *v
 	t.reduce()
 
-	var buf [8]byte
-	for i, l := range [5]uint64{t.l0, t.l1, t.l2, t.l3, t.l4} {
-		bitsOffset := i * 51
-		binary.LittleEndian.PutUint64(buf[:], l<<uint(bitsOffset%8))
-		for i, bb := range buf {
-			off := bitsOffset/8 + i
-			if off >= len(out) {
-				break
-			}
-			out[off] |= bb
-		}
-	}
+	// Recombine the reduced 51-bit limbs into four 64-bit words.
+	binary.LittleEndian.PutUint64(out[0:8], t.l0|t.l1<<51)
+	binary.LittleEndian.PutUint64(out[8:16], t.l1>>13|t.l2<<38)
+	binary.LittleEndian.PutUint64(out[16:24], t.l2>>26|t.l3<<25)
+	binary.LittleEndian.PutUint64(out[24:32], t.l3>>39|t.l4<<12)
 
 	return out[:]
 }
 
 // Equal returns 1 if v and u are equal, and 0 otherwise.
 func (v *Element) Equal(u *Element) int {
-	sa, sv := u.Bytes(), v.Bytes()
-	return subtle.ConstantTimeCompare(sa, sv)
+	// v - u is zero modulo p iff v and u are equal, and after the full
+	// reduction zero has a single representation, with all limbs zero.
+	var t Element
+	t.Subtract(v, u).reduce()
+	x := t.l0 | t.l1 | t.l2 | t.l3 | t.l4
+	// x is below 2^51, so x - 1 has the top bit set iff x is zero.
+	return int((x - 1) >> 63)
 }
 
 // mask64Bits returns 0xffffffff if cond is 1, and 0 otherwise.
@@ -298,13 +307,14 @@ func (v *Element) Absolute(u *Element) *Element {
 	return v.Select(new(Element).Negate(u), u, u.IsNegative())
 }
 
-// Multiply sets v = x * y, and returns v.
+// Multiply sets v = x * y, and returns v. x and y can be uncarried, see
+// AddNoCarry.
 func (v *Element) Multiply(x, y *Element) *Element {
 	feMul(v, x, y)
 	return v
 }
 
-// Square sets v = x * x, and returns v.
+// Square sets v = x * x, and returns v. x can be uncarried, see AddNoCarry.
 func (v *Element) Square(x *Element) *Element {
 	feSquare(v, x)
 	return v
@@ -394,7 +404,11 @@ var sqrtM1 = &Element{1718705420411056, 234908883556509,
 //
 // If u/v is square, SqrtRatio returns r and 1. If u/v is not square, SqrtRatio
 // sets r according to Section 4.3 of draft-irtf-cfrg-ristretto255-decaf448-00,
-// and returns r and 0.
+// and returns r and 0. In particular, if u is zero SqrtRatio returns (0, 1)
+// even if v is zero, and if v is zero and u is not, it returns (0, 0).
+//
+// SqrtRatio runs in constant time, regardless of the values of u and v and of
+// whether u/v is square. r may alias u or v.
 func (r *Element) SqrtRatio(u, v *Element) (R *Element, wasSquare int) {
 	t0 := new(Element)
 
@@ -406,6 +420,14 @@ func (r *Element) SqrtRatio(u, v *Element) (R *Element, wasSquare int) {
 
 	check := new(Element).Multiply(v, t0.Square(rr)) // check = v * r^2
 
+	return r, r.sqrtRatioSelect(rr, check, u)
+}
+
+// sqrtRatioSelect sets r to the non-negative square root of u/v, given a
+// candidate rr and check = v * rr², and returns whether u/v was square.
+func (r *Element) sqrtRatioSelect(rr, check, u *Element) (wasSquare int) {
+	t0 := new(Element)
+
 	uNeg := new(Element).Negate(u)
 	correctSignSqrt := check.Equal(u)
 	flippedSignSqrt := check.Equal(uNeg)
@@ -416,5 +438,5 @@ func (r *Element) SqrtRatio(u, v *Element) (R *Element, wasSquare int) {
 	rr.Select(rPrime, rr, flippedSignSqrt|flippedSignSqrtI)
 
 	r.Absolute(rr) // Choose the nonnegative square root.
-	return r, correctSignSqrt | flippedSignSqrt
+	return correctSignSqrt | flippedSignSqrt
 }
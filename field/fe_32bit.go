@@ -0,0 +1,16 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build (386 || arm || mips || mipsle || wasm) && !edwards25519_fiat
+// +build 386 arm mips mipsle wasm
+// +build !edwards25519_fiat
+
+package field
+
+// On these platforms bits.Mul64 is not a single instruction, so the radix
+// 2^25.5 multiplication in fe_generic32.go is faster.
+
+func feMul(v, x, y *Element) { feMul32(v, x, y) }
+
+func feSquare(v, x *Element) { feSquare32(v, x) }
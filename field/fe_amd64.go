@@ -1,7 +1,7 @@
 // Code generated by command: go run fe_amd64_asm.go -out ../fe_amd64.s -stubs ../fe_amd64.go -pkg field. DO NOT EDIT.
 
-//go:build amd64 && gc && !purego
-// +build amd64,gc,!purego
+//go:build amd64 && gc && !purego && !edwards25519_fiat
+// +build amd64,gc,!purego,!edwards25519_fiat
 
 package field
 
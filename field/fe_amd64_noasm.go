@@ -2,8 +2,14 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build !amd64 || !gc || purego
+//go:build (!amd64 || !gc || purego) && !386 && !arm && !mips && !mipsle && !wasm && !edwards25519_fiat
 // +build !amd64 !gc purego
+// +build !386
+// +build !arm
+// +build !mips
+// +build !mipsle
+// +build !wasm
+// +build !edwards25519_fiat
 
 package field
 
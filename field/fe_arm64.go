@@ -2,8 +2,8 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
-//go:build arm64 && gc && !purego
-// +build arm64,gc,!purego
+//go:build arm64 && gc && !purego && !edwards25519_fiat
+// +build arm64,gc,!purego,!edwards25519_fiat
 
 package field
 
@@ -15,6 +15,24 @@ func BenchmarkAdd(b *testing.B) {
 	}
 }
 
+func BenchmarkBytes(b *testing.B) {
+	x := new(Element).Add(feOne, feOne)
+	var out [32]byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.bytes(&out)
+	}
+}
+
+func BenchmarkEqual(b *testing.B) {
+	x := new(Element).One()
+	y := new(Element).Add(x, x)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Equal(y)
+	}
+}
+
 func BenchmarkMultiply(b *testing.B) {
 	x := new(Element).One()
 	y := new(Element).Add(x, x)
@@ -40,6 +58,14 @@ func BenchmarkInvert(b *testing.B) {
 	}
 }
 
+func BenchmarkInvertVarTime(b *testing.B) {
+	x := new(Element).Add(feOne, feOne)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.InvertVarTime(x)
+	}
+}
+
 func BenchmarkMult32(b *testing.B) {
 	x := new(Element).One()
 	b.ResetTimer()
@@ -47,3 +73,19 @@ func BenchmarkMult32(b *testing.B) {
 		x.Mult32(x, 0xaa42aa42)
 	}
 }
+
+func BenchmarkSqrtRatio(b *testing.B) {
+	x := new(Element).Add(feOne, feOne)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.SqrtRatio(x, feOne)
+	}
+}
+
+func BenchmarkSqrt(b *testing.B) {
+	x := new(Element).Add(feOne, feOne)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Sqrt(x)
+	}
+}
@@ -48,3 +48,168 @@ func (v *Element) SetWideBytes(x []byte) (*Element, error) {
 
 	return v.carryPropagate(), nil
 }
+
+// AppendBinary appends the canonical 32-byte little-endian encoding of v, as
+// returned by Bytes, to b and returns the extended buffer. It implements the
+// encoding.BinaryAppender interface, and never returns an error.
+func (v *Element) AppendBinary(b []byte) ([]byte, error) {
+	var buf [32]byte
+	return append(b, v.bytes(&buf)...), nil
+}
+
+// EqualVarTime returns 1 if v and u are equal, and 0 otherwise.
+//
+// Unlike Equal, EqualVarTime returns as soon as it finds a difference. Its
+// execution time depends on the inputs, so it must only be used with public
+// values.
+func (v *Element) EqualVarTime(u *Element) int {
+	a, b := *v, *u
+	a.reduce()
+	b.reduce()
+	if a.l0 != b.l0 || a.l1 != b.l1 || a.l2 != b.l2 || a.l3 != b.l3 || a.l4 != b.l4 {
+		return 0
+	}
+	return 1
+}
+
+// InvertVarTime sets v = 1/z mod p, and returns v. If z == 0, InvertVarTime
+// returns v = 0.
+//
+// InvertVarTime is about twice as fast as Invert, but its execution time
+// depends on z, so it must only be used with public values, such as the
+// coordinates of a public point being encoded.
+func (v *Element) InvertVarTime(z *Element) *Element {
+	return v.invertSafegcdVarTime(z)
+}
+
+// BytesWithNegation returns the canonical 32-byte little-endian encodings of v
+// and of -v, that is, of p - v.
+func (v *Element) BytesWithNegation() (pos, neg []byte) {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var out [64]byte
+	return v.bytesWithNegation(&out)
+}
+
+func (v *Element) bytesWithNegation(out *[64]byte) (pos, neg []byte) {
+	var n Element
+	n.Negate(v)
+	pos = v.bytes((*[32]byte)(out[:32]))
+	neg = n.bytes((*[32]byte)(out[32:]))
+	return pos, neg
+}
+
+// BytesWithSign returns the canonical 32-byte little-endian encoding of
+// whichever of v and -v has IsNegative() equal to negative, which must be 0
+// or 1. The selection is performed in constant time.
+//
+// Zero is its own negation and is never negative, so if v is zero the encoding
+// of zero is returned regardless of negative.
+func (v *Element) BytesWithSign(negative int) []byte {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var out [32]byte
+	return v.bytesWithSign(&out, negative)
+}
+
+func (v *Element) bytesWithSign(out *[32]byte, negative int) []byte {
+	var n, r Element
+	n.Negate(v)
+	r.Select(&n, v, v.IsNegative()^negative)
+	return r.bytes(out)
+}
+
+// Sqrt sets r to the non-negative square root of u, like SqrtRatio(u, 1), and
+// returns r and 1 if u is square. Otherwise, it returns r and 0, and r is set
+// as SqrtRatio would.
+//
+// Sqrt is slightly faster than SqrtRatio, and also runs in constant time.
+func (r *Element) Sqrt(u *Element) (R *Element, wasSquare int) {
+	t0 := new(Element)
+
+	// r = u * u^((p-5)/8)
+	rr := new(Element).Multiply(u, t0.Pow22523(u))
+	check := new(Element).Square(rr)
+
+	return r, r.sqrtRatioSelect(rr, check, u)
+}
+
+// InvSqrt sets r to the non-negative square root of 1/v, like SqrtRatio(1, v),
+// and returns r and 1 if v is square. Otherwise, it returns r and 0, and r is
+// set as SqrtRatio would. If v is zero, InvSqrt returns (0, 0).
+//
+// InvSqrt is slightly faster than SqrtRatio, and also runs in constant time.
+func (r *Element) InvSqrt(v *Element) (R *Element, wasSquare int) {
+	t0 := new(Element)
+
+	// r = v3 * v7^((p-5)/8)
+	v2 := new(Element).Square(v)
+	v3 := new(Element).Multiply(v2, v)
+	v7 := new(Element).Multiply(v3, t0.Square(v2))
+	rr := new(Element).Multiply(v3, t0.Pow22523(v7))
+
+	check := new(Element).Multiply(v, t0.Square(rr)) // check = v * r^2
+
+	return r, r.sqrtRatioSelect(rr, check, feOne)
+}
+
+// SqrtRatioBatch sets r[i] and wasSquare[i] to the results of
+// SqrtRatio(u[i], v[i]) for every i, and returns 1 if all ratios were square,
+// and 0 otherwise. The lengths of all slices must match.
+//
+// Each element still requires its own exponentiation, so SqrtRatioBatch is
+// not faster than calling SqrtRatio in a loop, but the combined result makes it
+// easy to reject a batch of encodings in constant time. SqrtRatioBatch runs in
+// constant time. r[i] may alias u[i] or v[i].
+func SqrtRatioBatch(r []Element, wasSquare []int, u, v []*Element) (allSquare int) {
+	if len(r) != len(u) || len(wasSquare) != len(u) || len(v) != len(u) {
+		panic("edwards25519: called SqrtRatioBatch with different size inputs")
+	}
+	allSquare = 1
+	for i := range r {
+		_, wasSquare[i] = r[i].SqrtRatio(u[i], v[i])
+		allSquare &= wasSquare[i]
+	}
+	return allSquare
+}
+
+// AddNoCarry sets v = a + b without carrying the limbs of the result, and
+// returns v.
+//
+// Every other method returns a carried Element. AddNoCarry and
+// SubtractNoCarry take carried inputs, and produce an uncarried result that
+// can only be passed to Multiply, Square, and Carry. This saves a carry chain
+// when the sum or difference is immediately multiplied, as in the point
+// addition formulas, for example (Y - X) * (Y' - X').
+func (v *Element) AddNoCarry(a, b *Element) *Element {
+	// The limbs of carried elements are at most 2^51 + 2^18, so the limbs of
+	// the sum are at most 2^52 + 2^19, well below the 2^53 that Multiply and
+	// Square can handle.
+	v.l0 = a.l0 + b.l0
+	v.l1 = a.l1 + b.l1
+	v.l2 = a.l2 + b.l2
+	v.l3 = a.l3 + b.l3
+	v.l4 = a.l4 + b.l4
+	return v
+}
+
+// SubtractNoCarry sets v = a - b without carrying the limbs of the result,
+// and returns v. The inputs must be carried, and the result can only be
+// passed to Multiply, Square, and Carry, like for AddNoCarry.
+func (v *Element) SubtractNoCarry(a, b *Element) *Element {
+	// As in Subtract, add 2 * p to avoid underflows, for a result with limbs
+	// below 2^51 + 2^18 + 2^52.
+	v.l0 = (a.l0 + 0xFFFFFFFFFFFDA) - b.l0
+	v.l1 = (a.l1 + 0xFFFFFFFFFFFFE) - b.l1
+	v.l2 = (a.l2 + 0xFFFFFFFFFFFFE) - b.l2
+	v.l3 = (a.l3 + 0xFFFFFFFFFFFFE) - b.l3
+	v.l4 = (a.l4 + 0xFFFFFFFFFFFFE) - b.l4
+	return v
+}
+
+// Carry sets v = a, with its limbs carried, and returns v. a can be the
+// uncarried result of AddNoCarry or SubtractNoCarry.
+func (v *Element) Carry(a *Element) *Element {
+	*v = *a
+	return v.carryPropagate()
+}
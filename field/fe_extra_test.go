@@ -5,6 +5,7 @@
 package field
 
 import (
+	"bytes"
 	"math/big"
 	"testing"
 	"testing/quick"
@@ -35,3 +36,220 @@ func TestSetWideBytes(t *testing.T) {
 	}
 
 }
+
+func TestAppendBinary(t *testing.T) {
+	f := func(fe Element, prefix []byte) bool {
+		out, err := fe.AppendBinary(prefix)
+		if err != nil {
+			return false
+		}
+		return bytes.Equal(out[:len(prefix)], prefix) &&
+			bytes.Equal(out[len(prefix):], fe.Bytes())
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestInvertVarTime(t *testing.T) {
+	f := func(x Element) bool {
+		var got, want Element
+		got.InvertVarTime(&x)
+		want.Invert(&x)
+		return got.Equal(&want) == 1 && isInBounds(&got) &&
+			x.InvertVarTime(&x) == &x && x == got
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	pPlusOne := Element{(1 << 51) - 18, maskLow51Bits, maskLow51Bits, maskLow51Bits, maskLow51Bits}
+	const maxLimb = 1<<52 - 1
+	for _, x := range []Element{*feZero, *feOne, *new(Element).Negate(feOne), pPlusOne,
+		{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}} {
+		if !f(x) {
+			t.Errorf("failed for %#v", x)
+		}
+	}
+}
+
+func TestEqualVarTime(t *testing.T) {
+	f := func(x, y Element) bool {
+		return x.EqualVarTime(&y) == x.Equal(&y) && x.EqualVarTime(&x) == 1
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+
+	// p + 1 is a non-canonical representation of one.
+	pPlusOne := Element{(1 << 51) - 18, maskLow51Bits, maskLow51Bits, maskLow51Bits, maskLow51Bits}
+	if pPlusOne.EqualVarTime(new(Element).One()) != 1 {
+		t.Error("p + 1 is not equal to one")
+	}
+	if pPlusOne.EqualVarTime(new(Element).Zero()) != 0 {
+		t.Error("p + 1 is equal to zero")
+	}
+}
+
+func TestBytesWithNegation(t *testing.T) {
+	f := func(fe Element) bool {
+		// Generate can produce limbs too large for Negate, which are never
+		// produced by the Element operations.
+		fe.reduce()
+		pos, neg := fe.BytesWithNegation()
+		n := new(Element).Negate(&fe)
+		if !bytes.Equal(pos, fe.Bytes()) || !bytes.Equal(neg, n.Bytes()) {
+			return false
+		}
+
+		for _, negative := range []int{0, 1} {
+			out := fe.BytesWithSign(negative)
+			r, err := new(Element).SetBytes(out)
+			if err != nil {
+				return false
+			}
+			if r.Equal(&fe) != 1 && r.Equal(n) != 1 {
+				return false
+			}
+			if fe.Equal(feZero) == 1 {
+				if r.Equal(feZero) != 1 {
+					return false
+				}
+			} else if r.IsNegative() != negative {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSqrtAndInvSqrt(t *testing.T) {
+	f := func(u Element) bool {
+		want, wantSquare := new(Element).SqrtRatio(&u, feOne)
+		got, wasSquare := new(Element).Sqrt(&u)
+		if got.Equal(want) != 1 || wasSquare != wantSquare {
+			return false
+		}
+		want, wantSquare = new(Element).SqrtRatio(feOne, &u)
+		got, wasSquare = new(Element).InvSqrt(&u)
+		return got.Equal(want) == 1 && wasSquare == wantSquare
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if r, wasSquare := new(Element).Sqrt(feZero); r.Equal(feZero) != 1 || wasSquare != 1 {
+		t.Errorf("Sqrt(0) = (%v, %v), want (0, 1)", r, wasSquare)
+	}
+	if r, wasSquare := new(Element).InvSqrt(feZero); r.Equal(feZero) != 1 || wasSquare != 0 {
+		t.Errorf("InvSqrt(0) = (%v, %v), want (0, 0)", r, wasSquare)
+	}
+	two := new(Element).Add(feOne, feOne)
+	if _, wasSquare := new(Element).Sqrt(two); wasSquare != 0 {
+		t.Error("Sqrt(2) was square")
+	}
+}
+
+func TestSqrtRatioBatch(t *testing.T) {
+	f := func(u, v [4]Element) bool {
+		r := make([]Element, len(u))
+		wasSquare := make([]int, len(u))
+		var us, vs []*Element
+		for i := range u {
+			us = append(us, &u[i])
+			vs = append(vs, &v[i])
+		}
+		allSquare := SqrtRatioBatch(r, wasSquare, us, vs)
+
+		wantAll := 1
+		for i := range u {
+			want, wantSquare := new(Element).SqrtRatio(&u[i], &v[i])
+			if r[i].Equal(want) != 1 || wasSquare[i] != wantSquare {
+				return false
+			}
+			wantAll &= wantSquare
+		}
+		return allSquare == wantAll
+	}
+	if err := quick.Check(f, quickCheckConfig(256)); err != nil {
+		t.Error(err)
+	}
+
+	// 4/1 is square, 2/1 is not.
+	two := new(Element).Add(feOne, feOne)
+	four := new(Element).Add(two, two)
+	r := make([]Element, 2)
+	wasSquare := make([]int, 2)
+	if SqrtRatioBatch(r[:1], wasSquare[:1], []*Element{four}, []*Element{feOne}) != 1 {
+		t.Error("4/1 was not square")
+	}
+	if SqrtRatioBatch(r, wasSquare, []*Element{four, two}, []*Element{feOne, feOne}) != 0 ||
+		wasSquare[0] != 1 || wasSquare[1] != 0 {
+		t.Errorf("got %v, expected [1 0]", wasSquare)
+	}
+}
+
+func TestNoCarry(t *testing.T) {
+	f := func(a, b, c, d Element) bool {
+		for _, x := range []*Element{&a, &b, &c, &d} {
+			x.Carry(x)
+		}
+		var sum, diff, got, want Element
+		sum.AddNoCarry(&a, &b)
+		diff.SubtractNoCarry(&c, &d)
+
+		got.Multiply(&sum, &diff)
+		want.Multiply(new(Element).Add(&a, &b), new(Element).Subtract(&c, &d))
+		if got.Equal(&want) != 1 || !isInBounds(&got) {
+			return false
+		}
+		got.Square(&diff)
+		want.Square(new(Element).Subtract(&c, &d))
+		if got.Equal(&want) != 1 || !isInBounds(&got) {
+			return false
+		}
+		got.Carry(&sum)
+		want.Add(&a, &b)
+		return got.Equal(&want) == 1 && isInBounds(&got)
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMultiplyUncarried(t *testing.T) {
+	// Multiply and Square must handle limbs up to 2^53 - 1.
+	const maxLimb = 1<<53 - 1
+	inputs := []Element{{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb},
+		{maxLimb, 0, maxLimb, 0, maxLimb}, {0, maxLimb, 0, maxLimb, 0}}
+	for _, x := range inputs {
+		for _, y := range inputs {
+			want := new(big.Int).Mul(x.toBigUncarried(), y.toBigUncarried())
+			want.Mod(want, bigP)
+			got := new(Element).Multiply(&x, &y)
+			if got.toBig().Cmp(want) != 0 || !isInBounds(got) {
+				t.Errorf("Multiply(%v, %v) = %v, want %v", x, y, got.toBig(), want)
+			}
+		}
+		want := new(big.Int).Mul(x.toBigUncarried(), x.toBigUncarried())
+		want.Mod(want, bigP)
+		got := new(Element).Square(&x)
+		if got.toBig().Cmp(want) != 0 || !isInBounds(got) {
+			t.Errorf("Square(%v) = %v, want %v", x, got.toBig(), want)
+		}
+	}
+}
+
+// toBigUncarried returns the integer represented by the limbs of v, without
+// reducing it.
+func (v *Element) toBigUncarried() *big.Int {
+	n := new(big.Int)
+	for _, l := range []uint64{v.l4, v.l3, v.l2, v.l1, v.l0} {
+		n.Lsh(n, 51)
+		n.Add(n, new(big.Int).SetUint64(l))
+	}
+	return n
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_fiat
+// +build edwards25519_fiat
+
+package field
+
+// With the edwards25519_fiat build tag, multiplication, squaring, and carry
+// propagation use the fiat-crypto code in fe_fiat25519.go on every platform,
+// instead of the assembly or the radix 2^25.5 code.
+//
+// fiat-crypto proves its bounds for inputs with loose limbs, below 2^52.7,
+// which covers every carried Element and the results of Add and Subtract.
+// The uncarried results of AddNoCarry, SubtractNoCarry, and SetWideBytes can
+// be larger, and rely on the same overflow analysis as fe_generic.go: the
+// products still fit in 128 bits, and carry only needs limbs below 2^63.
+//
+// fiat-crypto code comes under the following license.
+//
+//     Copyright (c) 2015-2020 The fiat-crypto Authors. All rights reserved.
+//
+//     Redistribution and use in source and binary forms, with or without
+//     modification, are permitted provided that the following conditions are
+//     met:
+//
+//         1. Redistributions of source code must retain the above copyright
+//         notice, this list of conditions and the following disclaimer.
+//
+//     THIS SOFTWARE IS PROVIDED BY the fiat-crypto authors "AS IS"
+//     AND ANY EXPRESS OR IMPLIED WARRANTIES, INCLUDING, BUT NOT LIMITED TO,
+//     THE IMPLIED WARRANTIES OF MERCHANTABILITY AND FITNESS FOR A PARTICULAR
+//     PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL Berkeley Software Design,
+//     Inc. BE LIABLE FOR ANY DIRECT, INDIRECT, INCIDENTAL, SPECIAL,
+//     EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING, BUT NOT LIMITED TO,
+//     PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS OF USE, DATA, OR
+//     PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND ON ANY THEORY OF
+//     LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT (INCLUDING
+//     NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE OF THIS
+//     SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+
+func (v *Element) fiatLoose() *fiat25519LooseFieldElement {
+	return &fiat25519LooseFieldElement{v.l0, v.l1, v.l2, v.l3, v.l4}
+}
+
+func (v *Element) setFiatTight(t *fiat25519TightFieldElement) *Element {
+	v.l0, v.l1, v.l2, v.l3, v.l4 = t[0], t[1], t[2], t[3], t[4]
+	return v
+}
+
+func feMul(v, x, y *Element) {
+	var out fiat25519TightFieldElement
+	fiat25519CarryMul(&out, x.fiatLoose(), y.fiatLoose())
+	v.setFiatTight(&out)
+}
+
+func feSquare(v, x *Element) {
+	var out fiat25519TightFieldElement
+	fiat25519CarrySquare(&out, x.fiatLoose())
+	v.setFiatTight(&out)
+}
+
+func (v *Element) carryPropagate() *Element {
+	var out fiat25519TightFieldElement
+	fiat25519Carry(&out, v.fiatLoose())
+	return v.setFiatTight(&out)
+}
@@ -0,0 +1,424 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_fiat
+// +build edwards25519_fiat
+
+// This file follows the output of the fiat-crypto unsaturated_solinas
+// generator for 2^255 - 19 with five 51-bit limbs, restricted to the three
+// operations the field package needs. To check it, regenerate with
+//
+//	unsaturated_solinas --lang Go --cmovznz-by-mul --relax-primitive-carry-to-bitwidth 32,64 --public-function-case camelCase --public-type-case camelCase --private-function-case camelCase --private-type-case camelCase --doc-text-before-function-name '' --doc-newline-before-package-declaration --package-name field 25519 64 '(auto)' '2^255 - 19' carry_mul carry_square carry
+//
+// and diff the function bodies. See fe_fiat.go for the license.
+//
+// Computed values:
+//
+//   eval z = z[0] + (z[1] << 51) + (z[2] << 102) + (z[3] << 153) + (z[4] << 204)
+
+package field
+
+import "math/bits"
+
+type fiat25519Uint1 uint64 // We use uint64 instead of a more narrow type for performance reasons; see https://github.com/mit-plv/fiat-crypto/pull/1006#issuecomment-892625927
+
+// The type fiat25519LooseFieldElement is a field element with loose bounds.
+//
+// Bounds: [[0x0 ~> 0x1a666666666664], [0x0 ~> 0x1a666666666664], [0x0 ~> 0x1a666666666664], [0x0 ~> 0x1a666666666664], [0x0 ~> 0x1a666666666664]]
+type fiat25519LooseFieldElement [5]uint64
+
+// The type fiat25519TightFieldElement is a field element with tight bounds.
+//
+// Bounds: [[0x0 ~> 0x8cccccccccccc], [0x0 ~> 0x8cccccccccccc], [0x0 ~> 0x8cccccccccccc], [0x0 ~> 0x8cccccccccccc], [0x0 ~> 0x8cccccccccccc]]
+type fiat25519TightFieldElement [5]uint64
+
+// fiat25519CarryMul multiplies two field elements and reduces the result.
+//
+// Postconditions:
+//
+//	eval out1 mod m = (eval arg1 * eval arg2) mod m
+func fiat25519CarryMul(out1 *fiat25519TightFieldElement, arg1 *fiat25519LooseFieldElement, arg2 *fiat25519LooseFieldElement) {
+	var x1 uint64
+	var x2 uint64
+	x2, x1 = bits.Mul64(arg1[4], (arg2[4] * 0x13))
+	var x3 uint64
+	var x4 uint64
+	x4, x3 = bits.Mul64(arg1[4], (arg2[3] * 0x13))
+	var x5 uint64
+	var x6 uint64
+	x6, x5 = bits.Mul64(arg1[4], (arg2[2] * 0x13))
+	var x7 uint64
+	var x8 uint64
+	x8, x7 = bits.Mul64(arg1[4], (arg2[1] * 0x13))
+	var x9 uint64
+	var x10 uint64
+	x10, x9 = bits.Mul64(arg1[3], (arg2[4] * 0x13))
+	var x11 uint64
+	var x12 uint64
+	x12, x11 = bits.Mul64(arg1[3], (arg2[3] * 0x13))
+	var x13 uint64
+	var x14 uint64
+	x14, x13 = bits.Mul64(arg1[3], (arg2[2] * 0x13))
+	var x15 uint64
+	var x16 uint64
+	x16, x15 = bits.Mul64(arg1[2], (arg2[4] * 0x13))
+	var x17 uint64
+	var x18 uint64
+	x18, x17 = bits.Mul64(arg1[2], (arg2[3] * 0x13))
+	var x19 uint64
+	var x20 uint64
+	x20, x19 = bits.Mul64(arg1[1], (arg2[4] * 0x13))
+	var x21 uint64
+	var x22 uint64
+	x22, x21 = bits.Mul64(arg1[4], arg2[0])
+	var x23 uint64
+	var x24 uint64
+	x24, x23 = bits.Mul64(arg1[3], arg2[1])
+	var x25 uint64
+	var x26 uint64
+	x26, x25 = bits.Mul64(arg1[3], arg2[0])
+	var x27 uint64
+	var x28 uint64
+	x28, x27 = bits.Mul64(arg1[2], arg2[2])
+	var x29 uint64
+	var x30 uint64
+	x30, x29 = bits.Mul64(arg1[2], arg2[1])
+	var x31 uint64
+	var x32 uint64
+	x32, x31 = bits.Mul64(arg1[2], arg2[0])
+	var x33 uint64
+	var x34 uint64
+	x34, x33 = bits.Mul64(arg1[1], arg2[3])
+	var x35 uint64
+	var x36 uint64
+	x36, x35 = bits.Mul64(arg1[1], arg2[2])
+	var x37 uint64
+	var x38 uint64
+	x38, x37 = bits.Mul64(arg1[1], arg2[1])
+	var x39 uint64
+	var x40 uint64
+	x40, x39 = bits.Mul64(arg1[1], arg2[0])
+	var x41 uint64
+	var x42 uint64
+	x42, x41 = bits.Mul64(arg1[0], arg2[4])
+	var x43 uint64
+	var x44 uint64
+	x44, x43 = bits.Mul64(arg1[0], arg2[3])
+	var x45 uint64
+	var x46 uint64
+	x46, x45 = bits.Mul64(arg1[0], arg2[2])
+	var x47 uint64
+	var x48 uint64
+	x48, x47 = bits.Mul64(arg1[0], arg2[1])
+	var x49 uint64
+	var x50 uint64
+	x50, x49 = bits.Mul64(arg1[0], arg2[0])
+	var x51 uint64
+	var x52 uint64
+	x51, x52 = bits.Add64(x19, x49, uint64(0x0))
+	var x53 uint64
+	x53, _ = bits.Add64(x20, x50, uint64(fiat25519Uint1(x52)))
+	var x54 uint64
+	var x55 uint64
+	x54, x55 = bits.Add64(x17, x51, uint64(0x0))
+	var x56 uint64
+	x56, _ = bits.Add64(x18, x53, uint64(fiat25519Uint1(x55)))
+	var x57 uint64
+	var x58 uint64
+	x57, x58 = bits.Add64(x13, x54, uint64(0x0))
+	var x59 uint64
+	x59, _ = bits.Add64(x14, x56, uint64(fiat25519Uint1(x58)))
+	var x60 uint64
+	var x61 uint64
+	x60, x61 = bits.Add64(x7, x57, uint64(0x0))
+	var x62 uint64
+	x62, _ = bits.Add64(x8, x59, uint64(fiat25519Uint1(x61)))
+	var x63 uint64
+	var x64 uint64
+	x63, x64 = bits.Add64(x39, x47, uint64(0x0))
+	var x65 uint64
+	x65, _ = bits.Add64(x40, x48, uint64(fiat25519Uint1(x64)))
+	var x66 uint64
+	var x67 uint64
+	x66, x67 = bits.Add64(x15, x63, uint64(0x0))
+	var x68 uint64
+	x68, _ = bits.Add64(x16, x65, uint64(fiat25519Uint1(x67)))
+	var x69 uint64
+	var x70 uint64
+	x69, x70 = bits.Add64(x11, x66, uint64(0x0))
+	var x71 uint64
+	x71, _ = bits.Add64(x12, x68, uint64(fiat25519Uint1(x70)))
+	var x72 uint64
+	var x73 uint64
+	x72, x73 = bits.Add64(x5, x69, uint64(0x0))
+	var x74 uint64
+	x74, _ = bits.Add64(x6, x71, uint64(fiat25519Uint1(x73)))
+	var x75 uint64
+	var x76 uint64
+	x75, x76 = bits.Add64(x37, x45, uint64(0x0))
+	var x77 uint64
+	x77, _ = bits.Add64(x38, x46, uint64(fiat25519Uint1(x76)))
+	var x78 uint64
+	var x79 uint64
+	x78, x79 = bits.Add64(x31, x75, uint64(0x0))
+	var x80 uint64
+	x80, _ = bits.Add64(x32, x77, uint64(fiat25519Uint1(x79)))
+	var x81 uint64
+	var x82 uint64
+	x81, x82 = bits.Add64(x9, x78, uint64(0x0))
+	var x83 uint64
+	x83, _ = bits.Add64(x10, x80, uint64(fiat25519Uint1(x82)))
+	var x84 uint64
+	var x85 uint64
+	x84, x85 = bits.Add64(x3, x81, uint64(0x0))
+	var x86 uint64
+	x86, _ = bits.Add64(x4, x83, uint64(fiat25519Uint1(x85)))
+	var x87 uint64
+	var x88 uint64
+	x87, x88 = bits.Add64(x35, x43, uint64(0x0))
+	var x89 uint64
+	x89, _ = bits.Add64(x36, x44, uint64(fiat25519Uint1(x88)))
+	var x90 uint64
+	var x91 uint64
+	x90, x91 = bits.Add64(x29, x87, uint64(0x0))
+	var x92 uint64
+	x92, _ = bits.Add64(x30, x89, uint64(fiat25519Uint1(x91)))
+	var x93 uint64
+	var x94 uint64
+	x93, x94 = bits.Add64(x25, x90, uint64(0x0))
+	var x95 uint64
+	x95, _ = bits.Add64(x26, x92, uint64(fiat25519Uint1(x94)))
+	var x96 uint64
+	var x97 uint64
+	x96, x97 = bits.Add64(x1, x93, uint64(0x0))
+	var x98 uint64
+	x98, _ = bits.Add64(x2, x95, uint64(fiat25519Uint1(x97)))
+	var x99 uint64
+	var x100 uint64
+	x99, x100 = bits.Add64(x33, x41, uint64(0x0))
+	var x101 uint64
+	x101, _ = bits.Add64(x34, x42, uint64(fiat25519Uint1(x100)))
+	var x102 uint64
+	var x103 uint64
+	x102, x103 = bits.Add64(x27, x99, uint64(0x0))
+	var x104 uint64
+	x104, _ = bits.Add64(x28, x101, uint64(fiat25519Uint1(x103)))
+	var x105 uint64
+	var x106 uint64
+	x105, x106 = bits.Add64(x23, x102, uint64(0x0))
+	var x107 uint64
+	x107, _ = bits.Add64(x24, x104, uint64(fiat25519Uint1(x106)))
+	var x108 uint64
+	var x109 uint64
+	x108, x109 = bits.Add64(x21, x105, uint64(0x0))
+	var x110 uint64
+	x110, _ = bits.Add64(x22, x107, uint64(fiat25519Uint1(x109)))
+	x111 := ((x62 << 13) | (x60 >> 51))
+	x112 := (x60 & 0x7ffffffffffff)
+	var x113 uint64
+	var x114 uint64
+	x113, x114 = bits.Add64(x72, x111, uint64(0x0))
+	x115 := (uint64(fiat25519Uint1(x114)) + x74)
+	x116 := ((x115 << 13) | (x113 >> 51))
+	x117 := (x113 & 0x7ffffffffffff)
+	var x118 uint64
+	var x119 uint64
+	x118, x119 = bits.Add64(x84, x116, uint64(0x0))
+	x120 := (uint64(fiat25519Uint1(x119)) + x86)
+	x121 := ((x120 << 13) | (x118 >> 51))
+	x122 := (x118 & 0x7ffffffffffff)
+	var x123 uint64
+	var x124 uint64
+	x123, x124 = bits.Add64(x96, x121, uint64(0x0))
+	x125 := (uint64(fiat25519Uint1(x124)) + x98)
+	x126 := ((x125 << 13) | (x123 >> 51))
+	x127 := (x123 & 0x7ffffffffffff)
+	var x128 uint64
+	var x129 uint64
+	x128, x129 = bits.Add64(x108, x126, uint64(0x0))
+	x130 := (uint64(fiat25519Uint1(x129)) + x110)
+	x131 := ((x130 << 13) | (x128 >> 51))
+	x132 := (x128 & 0x7ffffffffffff)
+	x133 := (x131 * 0x13)
+	x134 := (x112 + x133)
+	x135 := (x134 >> 51)
+	x136 := (x134 & 0x7ffffffffffff)
+	x137 := (x135 + x117)
+	out1[0] = x136
+	out1[1] = x137
+	out1[2] = x122
+	out1[3] = x127
+	out1[4] = x132
+}
+
+// fiat25519CarrySquare squares a field element and reduces the result.
+//
+// Postconditions:
+//
+//	eval out1 mod m = (eval arg1 * eval arg1) mod m
+func fiat25519CarrySquare(out1 *fiat25519TightFieldElement, arg1 *fiat25519LooseFieldElement) {
+	x1 := (arg1[4] * 0x13)
+	x2 := (x1 * 0x2)
+	x3 := (arg1[4] * 0x2)
+	x4 := (arg1[3] * 0x13)
+	x5 := (x4 * 0x2)
+	x6 := (arg1[3] * 0x2)
+	x7 := (arg1[2] * 0x2)
+	x8 := (arg1[1] * 0x2)
+	var x9 uint64
+	var x10 uint64
+	x10, x9 = bits.Mul64(arg1[4], x1)
+	var x11 uint64
+	var x12 uint64
+	x12, x11 = bits.Mul64(arg1[3], x2)
+	var x13 uint64
+	var x14 uint64
+	x14, x13 = bits.Mul64(arg1[3], x4)
+	var x15 uint64
+	var x16 uint64
+	x16, x15 = bits.Mul64(arg1[2], x2)
+	var x17 uint64
+	var x18 uint64
+	x18, x17 = bits.Mul64(arg1[2], x5)
+	var x19 uint64
+	var x20 uint64
+	x20, x19 = bits.Mul64(arg1[2], arg1[2])
+	var x21 uint64
+	var x22 uint64
+	x22, x21 = bits.Mul64(arg1[1], x2)
+	var x23 uint64
+	var x24 uint64
+	x24, x23 = bits.Mul64(arg1[1], x6)
+	var x25 uint64
+	var x26 uint64
+	x26, x25 = bits.Mul64(arg1[1], x7)
+	var x27 uint64
+	var x28 uint64
+	x28, x27 = bits.Mul64(arg1[1], arg1[1])
+	var x29 uint64
+	var x30 uint64
+	x30, x29 = bits.Mul64(arg1[0], x3)
+	var x31 uint64
+	var x32 uint64
+	x32, x31 = bits.Mul64(arg1[0], x6)
+	var x33 uint64
+	var x34 uint64
+	x34, x33 = bits.Mul64(arg1[0], x7)
+	var x35 uint64
+	var x36 uint64
+	x36, x35 = bits.Mul64(arg1[0], x8)
+	var x37 uint64
+	var x38 uint64
+	x38, x37 = bits.Mul64(arg1[0], arg1[0])
+	var x39 uint64
+	var x40 uint64
+	x39, x40 = bits.Add64(x17, x21, uint64(0x0))
+	var x41 uint64
+	x41, _ = bits.Add64(x18, x22, uint64(fiat25519Uint1(x40)))
+	var x42 uint64
+	var x43 uint64
+	x42, x43 = bits.Add64(x37, x39, uint64(0x0))
+	var x44 uint64
+	x44, _ = bits.Add64(x38, x41, uint64(fiat25519Uint1(x43)))
+	var x45 uint64
+	var x46 uint64
+	x45, x46 = bits.Add64(x13, x15, uint64(0x0))
+	var x47 uint64
+	x47, _ = bits.Add64(x14, x16, uint64(fiat25519Uint1(x46)))
+	var x48 uint64
+	var x49 uint64
+	x48, x49 = bits.Add64(x35, x45, uint64(0x0))
+	var x50 uint64
+	x50, _ = bits.Add64(x36, x47, uint64(fiat25519Uint1(x49)))
+	var x51 uint64
+	var x52 uint64
+	x51, x52 = bits.Add64(x27, x11, uint64(0x0))
+	var x53 uint64
+	x53, _ = bits.Add64(x28, x12, uint64(fiat25519Uint1(x52)))
+	var x54 uint64
+	var x55 uint64
+	x54, x55 = bits.Add64(x33, x51, uint64(0x0))
+	var x56 uint64
+	x56, _ = bits.Add64(x34, x53, uint64(fiat25519Uint1(x55)))
+	var x57 uint64
+	var x58 uint64
+	x57, x58 = bits.Add64(x25, x9, uint64(0x0))
+	var x59 uint64
+	x59, _ = bits.Add64(x26, x10, uint64(fiat25519Uint1(x58)))
+	var x60 uint64
+	var x61 uint64
+	x60, x61 = bits.Add64(x31, x57, uint64(0x0))
+	var x62 uint64
+	x62, _ = bits.Add64(x32, x59, uint64(fiat25519Uint1(x61)))
+	var x63 uint64
+	var x64 uint64
+	x63, x64 = bits.Add64(x23, x19, uint64(0x0))
+	var x65 uint64
+	x65, _ = bits.Add64(x24, x20, uint64(fiat25519Uint1(x64)))
+	var x66 uint64
+	var x67 uint64
+	x66, x67 = bits.Add64(x29, x63, uint64(0x0))
+	var x68 uint64
+	x68, _ = bits.Add64(x30, x65, uint64(fiat25519Uint1(x67)))
+	x69 := ((x44 << 13) | (x42 >> 51))
+	x70 := (x42 & 0x7ffffffffffff)
+	var x71 uint64
+	var x72 uint64
+	x71, x72 = bits.Add64(x48, x69, uint64(0x0))
+	x73 := (uint64(fiat25519Uint1(x72)) + x50)
+	x74 := ((x73 << 13) | (x71 >> 51))
+	x75 := (x71 & 0x7ffffffffffff)
+	var x76 uint64
+	var x77 uint64
+	x76, x77 = bits.Add64(x54, x74, uint64(0x0))
+	x78 := (uint64(fiat25519Uint1(x77)) + x56)
+	x79 := ((x78 << 13) | (x76 >> 51))
+	x80 := (x76 & 0x7ffffffffffff)
+	var x81 uint64
+	var x82 uint64
+	x81, x82 = bits.Add64(x60, x79, uint64(0x0))
+	x83 := (uint64(fiat25519Uint1(x82)) + x62)
+	x84 := ((x83 << 13) | (x81 >> 51))
+	x85 := (x81 & 0x7ffffffffffff)
+	var x86 uint64
+	var x87 uint64
+	x86, x87 = bits.Add64(x66, x84, uint64(0x0))
+	x88 := (uint64(fiat25519Uint1(x87)) + x68)
+	x89 := ((x88 << 13) | (x86 >> 51))
+	x90 := (x86 & 0x7ffffffffffff)
+	x91 := (x89 * 0x13)
+	x92 := (x70 + x91)
+	x93 := (x92 >> 51)
+	x94 := (x92 & 0x7ffffffffffff)
+	x95 := (x93 + x75)
+	out1[0] = x94
+	out1[1] = x95
+	out1[2] = x80
+	out1[3] = x85
+	out1[4] = x90
+}
+
+// fiat25519Carry reduces a field element.
+//
+// Postconditions:
+//
+//	eval out1 mod m = eval arg1 mod m
+func fiat25519Carry(out1 *fiat25519TightFieldElement, arg1 *fiat25519LooseFieldElement) {
+	x1 := arg1[0]
+	x2 := ((x1 >> 51) + arg1[1])
+	x3 := ((x2 >> 51) + arg1[2])
+	x4 := ((x3 >> 51) + arg1[3])
+	x5 := ((x4 >> 51) + arg1[4])
+	x6 := ((x1 & 0x7ffffffffffff) + ((x5 >> 51) * 0x13))
+	x7 := (uint64(fiat25519Uint1((x6 >> 51))) + (x2 & 0x7ffffffffffff))
+	x8 := (x6 & 0x7ffffffffffff)
+	x9 := (x3 & 0x7ffffffffffff)
+	x10 := (x4 & 0x7ffffffffffff)
+	x11 := (x5 & 0x7ffffffffffff)
+	out1[0] = x8
+	out1[1] = x7
+	out1[2] = x9
+	out1[3] = x10
+	out1[4] = x11
+}
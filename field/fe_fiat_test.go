@@ -0,0 +1,41 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_fiat
+// +build edwards25519_fiat
+
+package field
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+const fiatBackend = true
+
+func TestFiatCarry(t *testing.T) {
+	// fiat25519Carry can't take arbitrary 64-bit limbs like
+	// carryPropagateGeneric, but the package never produces limbs above 2^58.
+	const maxLimb = 1<<63 - 1
+	fiatLikeGeneric := func(a [5]uint64) bool {
+		for i := range a {
+			a[i] &= maxLimb
+		}
+		t1 := &Element{a[0], a[1], a[2], a[3], a[4]}
+		t2 := &Element{a[0], a[1], a[2], a[3], a[4]}
+
+		t1.carryPropagate()
+		t2.carryPropagateGeneric()
+
+		return t1.Equal(t2) == 1 && isInBounds(t1)
+	}
+
+	if err := quick.Check(fiatLikeGeneric, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if !fiatLikeGeneric([5]uint64{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}) {
+		t.Errorf("failed for {maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}")
+	}
+}
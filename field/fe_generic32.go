@@ -0,0 +1,298 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package field
+
+// On 32-bit platforms, the 64 x 64 -> 128 bit multiplications of
+// feMulGeneric are emulated with four 32 x 32 -> 64 bit multiplications each,
+// and the 128-bit accumulators need twice the additions. feMul32 and
+// feSquare32 instead split each 51-bit limb into a 26-bit and a 25-bit half,
+// for ten limbs in radix 2^25.5 like ref10, and multiply those with native
+// 32 x 32 -> 64 bit multiplications into 64-bit accumulators.
+//
+// The conversion to and from the 51-bit limbs is only a few shifts, so the
+// rest of the package keeps working on the 64-bit representation.
+
+// mul32 returns a * b, which compiles to a single multiplication on 32-bit
+// platforms.
+func mul32(a, b uint32) uint64 {
+	return uint64(a) * uint64(b)
+}
+
+const maskLow26Bits = (1 << 26) - 1
+const maskLow25Bits = (1 << 25) - 1
+
+// split returns the ten limbs of v in radix 2^25.5, which are at
+// bit positions 0, 26, 51, 77, 102, 128, 153, 179, 204, and 230. Since the
+// limbs of v are below 2^53, the even limbs are below 2^26 and the odd ones
+// below 2^27.
+func (v *Element) split() (l0, l1, l2, l3, l4, l5, l6, l7, l8, l9 uint32) {
+	return uint32(v.l0) & maskLow26Bits, uint32(v.l0 >> 26),
+		uint32(v.l1) & maskLow26Bits, uint32(v.l1 >> 26),
+		uint32(v.l2) & maskLow26Bits, uint32(v.l2 >> 26),
+		uint32(v.l3) & maskLow26Bits, uint32(v.l3 >> 26),
+		uint32(v.l4) & maskLow26Bits, uint32(v.l4 >> 26)
+}
+
+func feMul32(v, a, b *Element) {
+	a0, a1, a2, a3, a4, a5, a6, a7, a8, a9 := a.split()
+	b0, b1, b2, b3, b4, b5, b6, b7, b8, b9 := b.split()
+
+	// The product works like in feMulGeneric, with a few differences. The
+	// limb at position i in a and the one at position j in b multiply into
+	// the limb at position i + j, but when both i and j are odd the bit
+	// positions add up to half a bit more, so the product must be doubled.
+	// For example, 2^26 * 2^77 = 2 * 2^102. As before, the terms that
+	// overflow 255 bits are multiplied by 19 and wrapped around.
+	//
+	// The limbs are below 2^27, so the factors are below 2^28 and
+	// 19 * 2^27 < 2^32, and the ten terms of a column add up to less than
+	// 2^62, since the odd limbs can only be doubled with each other.
+
+	a1_2 := a1 * 2
+	a3_2 := a3 * 2
+	a5_2 := a5 * 2
+	a7_2 := a7 * 2
+	a9_2 := a9 * 2
+
+	b1_19 := b1 * 19
+	b2_19 := b2 * 19
+	b3_19 := b3 * 19
+	b4_19 := b4 * 19
+	b5_19 := b5 * 19
+	b6_19 := b6 * 19
+	b7_19 := b7 * 19
+	b8_19 := b8 * 19
+	b9_19 := b9 * 19
+
+	h0 := mul32(a0, b0) +
+		mul32(a1_2, b9_19) +
+		mul32(a2, b8_19) +
+		mul32(a3_2, b7_19) +
+		mul32(a4, b6_19) +
+		mul32(a5_2, b5_19) +
+		mul32(a6, b4_19) +
+		mul32(a7_2, b3_19) +
+		mul32(a8, b2_19) +
+		mul32(a9_2, b1_19)
+	h1 := mul32(a0, b1) +
+		mul32(a1, b0) +
+		mul32(a2, b9_19) +
+		mul32(a3, b8_19) +
+		mul32(a4, b7_19) +
+		mul32(a5, b6_19) +
+		mul32(a6, b5_19) +
+		mul32(a7, b4_19) +
+		mul32(a8, b3_19) +
+		mul32(a9, b2_19)
+	h2 := mul32(a0, b2) +
+		mul32(a1_2, b1) +
+		mul32(a2, b0) +
+		mul32(a3_2, b9_19) +
+		mul32(a4, b8_19) +
+		mul32(a5_2, b7_19) +
+		mul32(a6, b6_19) +
+		mul32(a7_2, b5_19) +
+		mul32(a8, b4_19) +
+		mul32(a9_2, b3_19)
+	h3 := mul32(a0, b3) +
+		mul32(a1, b2) +
+		mul32(a2, b1) +
+		mul32(a3, b0) +
+		mul32(a4, b9_19) +
+		mul32(a5, b8_19) +
+		mul32(a6, b7_19) +
+		mul32(a7, b6_19) +
+		mul32(a8, b5_19) +
+		mul32(a9, b4_19)
+	h4 := mul32(a0, b4) +
+		mul32(a1_2, b3) +
+		mul32(a2, b2) +
+		mul32(a3_2, b1) +
+		mul32(a4, b0) +
+		mul32(a5_2, b9_19) +
+		mul32(a6, b8_19) +
+		mul32(a7_2, b7_19) +
+		mul32(a8, b6_19) +
+		mul32(a9_2, b5_19)
+	h5 := mul32(a0, b5) +
+		mul32(a1, b4) +
+		mul32(a2, b3) +
+		mul32(a3, b2) +
+		mul32(a4, b1) +
+		mul32(a5, b0) +
+		mul32(a6, b9_19) +
+		mul32(a7, b8_19) +
+		mul32(a8, b7_19) +
+		mul32(a9, b6_19)
+	h6 := mul32(a0, b6) +
+		mul32(a1_2, b5) +
+		mul32(a2, b4) +
+		mul32(a3_2, b3) +
+		mul32(a4, b2) +
+		mul32(a5_2, b1) +
+		mul32(a6, b0) +
+		mul32(a7_2, b9_19) +
+		mul32(a8, b8_19) +
+		mul32(a9_2, b7_19)
+	h7 := mul32(a0, b7) +
+		mul32(a1, b6) +
+		mul32(a2, b5) +
+		mul32(a3, b4) +
+		mul32(a4, b3) +
+		mul32(a5, b2) +
+		mul32(a6, b1) +
+		mul32(a7, b0) +
+		mul32(a8, b9_19) +
+		mul32(a9, b8_19)
+	h8 := mul32(a0, b8) +
+		mul32(a1_2, b7) +
+		mul32(a2, b6) +
+		mul32(a3_2, b5) +
+		mul32(a4, b4) +
+		mul32(a5_2, b3) +
+		mul32(a6, b2) +
+		mul32(a7_2, b1) +
+		mul32(a8, b0) +
+		mul32(a9_2, b9_19)
+	h9 := mul32(a0, b9) +
+		mul32(a1, b8) +
+		mul32(a2, b7) +
+		mul32(a3, b6) +
+		mul32(a4, b5) +
+		mul32(a5, b4) +
+		mul32(a6, b3) +
+		mul32(a7, b2) +
+		mul32(a8, b1) +
+		mul32(a9, b0)
+
+	v.combine(h0, h1, h2, h3, h4, h5, h6, h7, h8, h9)
+}
+
+func feSquare32(v, a *Element) {
+	l0, l1, l2, l3, l4, l5, l6, l7, l8, l9 := a.split()
+
+	// Squaring works like feMul32, but as in feSquareGeneric the symmetric
+	// terms are grouped together and doubled. The factors are at most
+	// 4 * 2^27 and 19 * 2^27 < 2^32.
+
+	l0_2 := l0 * 2
+	l1_2 := l1 * 2
+	l2_2 := l2 * 2
+	l3_2 := l3 * 2
+	l4_2 := l4 * 2
+	l5_2 := l5 * 2
+	l6_2 := l6 * 2
+	l7_2 := l7 * 2
+	l8_2 := l8 * 2
+	l9_2 := l9 * 2
+
+	l1_4 := l1 * 4
+	l3_4 := l3 * 4
+	l5_4 := l5 * 4
+	l7_4 := l7 * 4
+
+	l5_19 := l5 * 19
+	l6_19 := l6 * 19
+	l7_19 := l7 * 19
+	l8_19 := l8 * 19
+	l9_19 := l9 * 19
+
+	h0 := mul32(l0, l0) +
+		mul32(l1_4, l9_19) +
+		mul32(l2_2, l8_19) +
+		mul32(l3_4, l7_19) +
+		mul32(l4_2, l6_19) +
+		mul32(l5_2, l5_19)
+	h1 := mul32(l0_2, l1) +
+		mul32(l2_2, l9_19) +
+		mul32(l3_2, l8_19) +
+		mul32(l4_2, l7_19) +
+		mul32(l5_2, l6_19)
+	h2 := mul32(l0_2, l2) +
+		mul32(l1_2, l1) +
+		mul32(l3_4, l9_19) +
+		mul32(l4_2, l8_19) +
+		mul32(l5_4, l7_19) +
+		mul32(l6, l6_19)
+	h3 := mul32(l0_2, l3) +
+		mul32(l1_2, l2) +
+		mul32(l4_2, l9_19) +
+		mul32(l5_2, l8_19) +
+		mul32(l6_2, l7_19)
+	h4 := mul32(l0_2, l4) +
+		mul32(l1_4, l3) +
+		mul32(l2, l2) +
+		mul32(l5_4, l9_19) +
+		mul32(l6_2, l8_19) +
+		mul32(l7_2, l7_19)
+	h5 := mul32(l0_2, l5) +
+		mul32(l1_2, l4) +
+		mul32(l2_2, l3) +
+		mul32(l6_2, l9_19) +
+		mul32(l7_2, l8_19)
+	h6 := mul32(l0_2, l6) +
+		mul32(l1_4, l5) +
+		mul32(l2_2, l4) +
+		mul32(l3_2, l3) +
+		mul32(l7_4, l9_19) +
+		mul32(l8, l8_19)
+	h7 := mul32(l0_2, l7) +
+		mul32(l1_2, l6) +
+		mul32(l2_2, l5) +
+		mul32(l3_2, l4) +
+		mul32(l8_2, l9_19)
+	h8 := mul32(l0_2, l8) +
+		mul32(l1_4, l7) +
+		mul32(l2_2, l6) +
+		mul32(l3_4, l5) +
+		mul32(l4, l4) +
+		mul32(l9_2, l9_19)
+	h9 := mul32(l0_2, l9) +
+		mul32(l1_2, l8) +
+		mul32(l2_2, l7) +
+		mul32(l3_2, l6) +
+		mul32(l4_2, l5)
+
+	v.combine(h0, h1, h2, h3, h4, h5, h6, h7, h8, h9)
+}
+
+// combine sets v to the sum of the ten radix 2^25.5 coefficients h0 to h9,
+// which must be below 2^62, carrying them into limbs below 2^52.
+func (v *Element) combine(h0, h1, h2, h3, h4, h5, h6, h7, h8, h9 uint64) {
+	// Carry each coefficient into the next one, wrapping the top carry around
+	// multiplied by 19 by the reduction identity. The carries are at most
+	// 62 - 25 = 37 bits, so they don't overflow the next coefficient, and
+	// after the chain every coefficient except h0 fits in its 25 or 26 bits.
+	// h0 is at most 2^26 + 19 * 2^37, and one more carry into h1 leaves it
+	// below 2^26 and h1 below 2^25 + 2^16.
+	h1 += h0 >> 26
+	h0 &= maskLow26Bits
+	h2 += h1 >> 25
+	h1 &= maskLow25Bits
+	h3 += h2 >> 26
+	h2 &= maskLow26Bits
+	h4 += h3 >> 25
+	h3 &= maskLow25Bits
+	h5 += h4 >> 26
+	h4 &= maskLow26Bits
+	h6 += h5 >> 25
+	h5 &= maskLow25Bits
+	h7 += h6 >> 26
+	h6 &= maskLow26Bits
+	h8 += h7 >> 25
+	h7 &= maskLow25Bits
+	h9 += h8 >> 26
+	h8 &= maskLow26Bits
+	h0 += (h9 >> 25) * 19
+	h9 &= maskLow25Bits
+	h1 += h0 >> 26
+	h0 &= maskLow26Bits
+
+	v.l0 = h0 | h1<<26
+	v.l1 = h2 | h3<<26
+	v.l2 = h4 | h5<<26
+	v.l3 = h6 | h7<<26
+	v.l4 = h8 | h9<<26
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package field
+
+import "filippo.io/edwards25519/internal/safegcd"
+
+// This file implements inversion with the safegcd algorithm, which is shared
+// with scalar inversion in the internal/safegcd package. See there for more
+// details. Only the parts that depend on p are here.
+
+// pSigned62 is p = 2^255 - 19 = -19 + 128 * 2^248.
+var pSigned62 = safegcd.Signed62{-19, 0, 0, 0, 128}
+
+// pInv62 is 1 / p mod 2^62.
+const pInv62 = 0x39435e50d79435e5
+
+const mask62 = safegcd.Mask62
+
+// invertSafegcd sets v = 1 / z mod p, and returns v. If z == 0, it returns
+// v = 0.
+func (v *Element) invertSafegcd(z *Element) *Element {
+	var inv safegcd.Inverter
+	inv.Init(z.toSigned62(), &pSigned62)
+	for inv.Next() {
+		updateDE(&inv.D, &inv.E, &inv.T)
+	}
+	d := inv.Result()
+	return v.fromSigned62(&d)
+}
+
+// invertSafegcdVarTime works like invertSafegcd, but in variable time.
+func (v *Element) invertSafegcdVarTime(z *Element) *Element {
+	var inv safegcd.Inverter
+	inv.InitVarTime(z.toSigned62(), &pSigned62)
+	for inv.Next() {
+		updateDE(&inv.D, &inv.E, &inv.T)
+	}
+	d := inv.Result()
+	return v.fromSigned62(&d)
+}
+
+// updateDE sets d, e = t * [d, e] / 2^62 mod p, with d and e in (-2p, p).
+func updateDE(d, e *safegcd.Signed62, t *[4]int64) {
+	u, v, q, r := t[0], t[1], t[2], t[3]
+
+	// Add a multiple of p to make the bottom 62 bits of the product zero, so
+	// it can be divided by 2^62. md and me start as [u, q] if d is negative,
+	// plus [v, r] if e is negative, to keep the results in range.
+	sd, se := d[4]>>63, e[4]>>63
+	md := (u & sd) + (v & se)
+	me := (q & sd) + (r & se)
+	var cd, ce safegcd.Int128
+	cd = cd.AddMul(u, d[0]).AddMul(v, e[0])
+	ce = ce.AddMul(q, d[0]).AddMul(r, e[0])
+	md -= int64((pInv62*cd.Lo + uint64(md)) & mask62)
+	me -= int64((pInv62*ce.Lo + uint64(me)) & mask62)
+	cd = cd.AddMul(pSigned62[0], md).Shr62()
+	ce = ce.AddMul(pSigned62[0], me).Shr62()
+
+	// The middle limbs of p are zero.
+	for i := 1; i < 4; i++ {
+		cd = cd.AddMul(u, d[i]).AddMul(v, e[i])
+		ce = ce.AddMul(q, d[i]).AddMul(r, e[i])
+		d[i-1] = int64(cd.Lo & mask62)
+		e[i-1] = int64(ce.Lo & mask62)
+		cd, ce = cd.Shr62(), ce.Shr62()
+	}
+
+	cd = cd.AddMul(u, d[4]).AddMul(v, e[4]).AddMul(pSigned62[4], md)
+	ce = ce.AddMul(q, d[4]).AddMul(r, e[4]).AddMul(pSigned62[4], me)
+	d[3] = int64(cd.Lo & mask62)
+	e[3] = int64(ce.Lo & mask62)
+	cd, ce = cd.Shr62(), ce.Shr62()
+	d[4] = int64(cd.Lo)
+	e[4] = int64(ce.Lo)
+}
+
+// toSigned62 returns v, fully reduced, as a safegcd.Signed62.
+func (v *Element) toSigned62() safegcd.Signed62 {
+	t := *v
+	t.reduce()
+	w0 := t.l0 | t.l1<<51
+	w1 := t.l1>>13 | t.l2<<38
+	w2 := t.l2>>26 | t.l3<<25
+	w3 := t.l3>>39 | t.l4<<12
+	return safegcd.Signed62{
+		int64(w0 & mask62),
+		int64((w0>>62 | w1<<2) & mask62),
+		int64((w1>>60 | w2<<4) & mask62),
+		int64((w2>>58 | w3<<6) & mask62),
+		int64(w3 >> 56),
+	}
+}
+
+// fromSigned62 sets v to x, which must be in [0, p), and returns v.
+func (v *Element) fromSigned62(x *safegcd.Signed62) *Element {
+	s0, s1, s2, s3, s4 := uint64(x[0]), uint64(x[1]), uint64(x[2]), uint64(x[3]), uint64(x[4])
+	v.l0 = s0 & maskLow51Bits
+	v.l1 = (s0>>51 | s1<<11) & maskLow51Bits
+	v.l2 = (s1>>40 | s2<<22) & maskLow51Bits
+	v.l3 = (s2>>29 | s3<<33) & maskLow51Bits
+	v.l4 = (s3>>18 | s4<<44) & maskLow51Bits
+	return v
+}
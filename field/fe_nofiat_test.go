@@ -0,0 +1,10 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !edwards25519_fiat
+// +build !edwards25519_fiat
+
+package field
+
+const fiatBackend = false
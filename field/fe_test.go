@@ -341,6 +341,23 @@ func TestEqual(t *testing.T) {
 	if eq != 0 {
 		t.Errorf("wrong about inequality")
 	}
+
+	// p + 1 and 1 are different representations of the same element.
+	pPlusOne := Element{(1 << 51) - 18, (1 << 51) - 1, (1 << 51) - 1, (1 << 51) - 1, (1 << 51) - 1}
+	if pPlusOne.Equal(feOne) != 1 || feOne.Equal(&pPlusOne) != 1 {
+		t.Errorf("wrong about equality of non-canonical representations")
+	}
+
+	f := func(x, y Element) bool {
+		want := 0
+		if bytes.Equal(x.Bytes(), y.Bytes()) {
+			want = 1
+		}
+		return x.Equal(&y) == want && x.Equal(&x) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
 }
 
 func TestInvert(t *testing.T) {
@@ -381,6 +398,33 @@ func TestInvert(t *testing.T) {
 	}
 }
 
+func TestInvertSafegcd(t *testing.T) {
+	f := func(x Element) bool {
+		var got, want Element
+		got.invertSafegcd(&x)
+		want.invertFermat(&x)
+		if got.Equal(&want) != 1 {
+			t.Logf("got: %#v,\nexpected: %#v", got, want)
+		}
+		return got.Equal(&want) == 1 && isInBounds(&got) && x.invertSafegcd(&x) == &x && x == got
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	// Zero, one, minus one, and non-canonical encodings of small values.
+	var minusOne Element
+	minusOne.Negate(feOne)
+	pPlusOne := Element{(1 << 51) - 18, (1 << 51) - 1, (1 << 51) - 1, (1 << 51) - 1, (1 << 51) - 1}
+	const maxLimb = 1<<52 - 1
+	for _, x := range []Element{*feZero, *feOne, minusOne, pPlusOne,
+		{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}} {
+		if !f(x) {
+			t.Errorf("failed for %#v", x)
+		}
+	}
+}
+
 func TestSelectSwap(t *testing.T) {
 	a := Element{358744748052810, 1691584618240980, 977650209285361, 1429865912637724, 560044844278676}
 	b := Element{84926274344903, 473620666599931, 365590438845504, 1028470286882429, 2146499180330972}
@@ -508,7 +552,8 @@ func TestCarryPropagate(t *testing.T) {
 		t.Error(err)
 	}
 
-	if !asmLikeGeneric([5]uint64{0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}) {
+	// The fiat-crypto carry is checked with bounded limbs in TestFiatCarry.
+	if !fiatBackend && !asmLikeGeneric([5]uint64{0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}) {
 		t.Errorf("failed for {0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}")
 	}
 }
@@ -521,11 +566,13 @@ func TestFeSquare(t *testing.T) {
 		feSquareGeneric(&t1, &t1)
 		feSquare(&t2, &t2)
 
-		if t1 != t2 {
+		// On 32-bit platforms, feSquare is feSquare32, which can return a
+		// different representation of the same value.
+		if t1.Equal(&t2) != 1 {
 			t.Logf("got: %#v,\nexpected: %#v", t1, t2)
 		}
 
-		return t1 == t2 && isInBounds(&t2)
+		return t1.Equal(&t2) == 1 && isInBounds(&t2)
 	}
 
 	if err := quick.Check(asmLikeGeneric, quickCheckConfig(1024)); err != nil {
@@ -543,12 +590,12 @@ func TestFeMul(t *testing.T) {
 		feMulGeneric(&a1, &a1, &b1)
 		feMul(&a2, &a2, &b2)
 
-		if a1 != a2 || b1 != b2 {
+		if a1.Equal(&a2) != 1 || b1 != b2 {
 			t.Logf("got: %#v,\nexpected: %#v", a1, a2)
 			t.Logf("got: %#v,\nexpected: %#v", b1, b2)
 		}
 
-		return a1 == a2 && isInBounds(&a2) &&
+		return a1.Equal(&a2) == 1 && isInBounds(&a2) &&
 			b1 == b2 && isInBounds(&b2)
 	}
 
@@ -557,6 +604,49 @@ func TestFeMul(t *testing.T) {
 	}
 }
 
+func TestFeMul32(t *testing.T) {
+	f := func(a, b Element) bool {
+		var want, got Element
+		feMulGeneric(&want, &a, &b)
+		feMul32(&got, &a, &b)
+		if got.Equal(&want) != 1 {
+			t.Logf("got: %#v,\nexpected: %#v", got, want)
+		}
+		feMul32(&a, &a, &b) // aliasing
+		return got.Equal(&want) == 1 && isInBounds(&got) && a == got
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	const maxLimb = 1<<52 - 1
+	max := Element{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}
+	if !f(max, max) {
+		t.Error("failed for maximum limbs")
+	}
+}
+
+func TestFeSquare32(t *testing.T) {
+	f := func(a Element) bool {
+		var want, got Element
+		feSquareGeneric(&want, &a)
+		feSquare32(&got, &a)
+		if got.Equal(&want) != 1 {
+			t.Logf("got: %#v,\nexpected: %#v", got, want)
+		}
+		feSquare32(&a, &a) // aliasing
+		return got.Equal(&want) == 1 && isInBounds(&got) && a == got
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	const maxLimb = 1<<52 - 1
+	if !f(Element{maxLimb, maxLimb, maxLimb, maxLimb, maxLimb}) {
+		t.Error("failed for maximum limbs")
+	}
+}
+
 func decodeHex(s string) []byte {
 	b, err := hex.DecodeString(s)
 	if err != nil {
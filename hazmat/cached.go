@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hazmat
+
+import (
+	"filippo.io/edwards25519/field"
+	"filippo.io/edwards25519/internal/formulas"
+)
+
+// Cached is a point in the cached representation (Y+X, Y-X, Z, 2dT) used as
+// the second operand of the addition formulas, where (X:Y:Z:T) are extended
+// coordinates. Converting a point to Cached once and reusing it saves work
+// when it is added multiple times.
+type Cached struct {
+	YplusX, YminusX, Z, T2d field.Element
+}
+
+// AffineCached is a point in the cached representation with Z = 1, (y+x, y-x,
+// 2dxy), also known as Niels coordinates. Addition of an AffineCached point is
+// cheaper than addition of a Cached point, but computing it requires a field
+// inversion, so it's best suited for precomputed tables.
+type AffineCached struct {
+	YplusX, YminusX, T2d field.Element
+}
+
+// Zero sets v to the identity element, and returns v.
+func (v *Cached) Zero() *Cached {
+	v.YplusX.One()
+	v.YminusX.One()
+	v.Z.One()
+	v.T2d.Zero()
+	return v
+}
+
+// Zero sets v to the identity element, and returns v.
+func (v *AffineCached) Zero() *AffineCached {
+	v.YplusX.One()
+	v.YminusX.One()
+	v.T2d.Zero()
+	return v
+}
+
+// FromP3 sets v = p, and returns v.
+func (v *Cached) FromP3(p *P3) *Cached {
+	(*formulas.Cached)(v).FromP3(&p.X, &p.Y, &p.Z, &p.T)
+	return v
+}
+
+// FromP3 sets v = p, and returns v. It performs a field inversion.
+func (v *AffineCached) FromP3(p *P3) *AffineCached {
+	var invZ field.Element
+	invZ.Invert(&p.Z)
+	(*formulas.AffineCached)(v).FromP3ZInv(&p.X, &p.Y, &p.T, &invZ)
+	return v
+}
+
+// Select sets v to a if cond == 1 and to b if cond == 0, in constant time.
+func (v *Cached) Select(a, b *Cached, cond int) *Cached {
+	(*formulas.Cached)(v).Select((*formulas.Cached)(a), (*formulas.Cached)(b), cond)
+	return v
+}
+
+// Select sets v to a if cond == 1 and to b if cond == 0, in constant time.
+func (v *AffineCached) Select(a, b *AffineCached, cond int) *AffineCached {
+	(*formulas.AffineCached)(v).Select((*formulas.AffineCached)(a), (*formulas.AffineCached)(b), cond)
+	return v
+}
+
+// CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0, in
+// constant time, and returns v.
+func (v *Cached) CondNeg(cond int) *Cached {
+	(*formulas.Cached)(v).CondNeg(cond)
+	return v
+}
+
+// CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0, in
+// constant time, and returns v.
+func (v *AffineCached) CondNeg(cond int) *AffineCached {
+	(*formulas.AffineCached)(v).CondNeg(cond)
+	return v
+}
+
+// (Re)addition and subtraction.
+
+// Add sets v = p + q, and returns v.
+func (v *P1xP1) Add(p *P3, q *Cached) *P1xP1 {
+	(*formulas.P1xP1)(v).Add(&p.X, &p.Y, &p.Z, &p.T, (*formulas.Cached)(q))
+	return v
+}
+
+// Sub sets v = p - q, and returns v.
+func (v *P1xP1) Sub(p *P3, q *Cached) *P1xP1 {
+	(*formulas.P1xP1)(v).Sub(&p.X, &p.Y, &p.Z, &p.T, (*formulas.Cached)(q))
+	return v
+}
+
+// AddAffine sets v = p + q, and returns v.
+func (v *P1xP1) AddAffine(p *P3, q *AffineCached) *P1xP1 {
+	(*formulas.P1xP1)(v).AddAffine(&p.X, &p.Y, &p.Z, &p.T, (*formulas.AffineCached)(q))
+	return v
+}
+
+// SubAffine sets v = p - q, and returns v.
+func (v *P1xP1) SubAffine(p *P3, q *AffineCached) *P1xP1 {
+	(*formulas.P1xP1)(v).SubAffine(&p.X, &p.Y, &p.Z, &p.T, (*formulas.AffineCached)(q))
+	return v
+}
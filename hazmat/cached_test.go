@@ -0,0 +1,100 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hazmat
+
+import (
+	"testing"
+	"testing/quick"
+
+	"filippo.io/edwards25519"
+)
+
+func TestCachedAddSub(t *testing.T) {
+	f := func(seedP, seedQ [64]byte, neg bool) bool {
+		p, q := randomPoint(seedP), randomPoint(seedQ)
+		var p3, q3 P3
+		p3.FromPoint(p)
+		q3.FromPoint(q)
+
+		cond := 0
+		if neg {
+			cond = 1
+		}
+		var qc Cached
+		var qa AffineCached
+		qc.FromP3(&q3).CondNeg(cond)
+		qa.FromP3(&q3).CondNeg(cond)
+
+		sum := new(edwards25519.Point).Add(p, q)
+		diff := new(edwards25519.Point).Subtract(p, q)
+		if neg {
+			sum, diff = diff, sum
+		}
+
+		var r P1xP1
+		var r3 P3
+		check := func(want *edwards25519.Point) bool {
+			got, err := r3.FromP1xP1(&r).ToPoint(new(edwards25519.Point))
+			return err == nil && got.Equal(want) == 1
+		}
+		r.Add(&p3, &qc)
+		if !check(sum) {
+			return false
+		}
+		r.Sub(&p3, &qc)
+		if !check(diff) {
+			return false
+		}
+		r.AddAffine(&p3, &qa)
+		if !check(sum) {
+			return false
+		}
+		r.SubAffine(&p3, &qa)
+		return check(diff)
+	}
+	if err := quick.Check(f, quickCheckConfig); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCachedSelectZero(t *testing.T) {
+	p := randomPoint([64]byte{1})
+	var p3, id3 P3
+	p3.FromPoint(p)
+	id3.Zero()
+
+	var a, b, c Cached
+	a.FromP3(&p3)
+	b.Zero()
+	if c.Select(&a, &b, 1) != &c || c != a {
+		t.Error("Select(a, b, 1) != a")
+	}
+	if c.Select(&a, &b, 0); c != b {
+		t.Error("Select(a, b, 0) != b")
+	}
+
+	var aa, ab, ac AffineCached
+	aa.FromP3(&p3)
+	ab.Zero()
+	if ac.Select(&aa, &ab, 1); ac != aa {
+		t.Error("Select(a, b, 1) != a")
+	}
+	if ac.Select(&aa, &ab, 0); ac != ab {
+		t.Error("Select(a, b, 0) != b")
+	}
+
+	// p + 0 = p, for both representations of 0.
+	var r P1xP1
+	var r3 P3
+	for _, got := range []*P3{
+		r3.FromP1xP1(r.Add(&p3, &b)),
+		new(P3).FromP1xP1(r.AddAffine(&p3, &ab)),
+	} {
+		q, err := got.ToPoint(new(edwards25519.Point))
+		if err != nil || q.Equal(p) != 1 {
+			t.Error("p + 0 != p")
+		}
+	}
+}
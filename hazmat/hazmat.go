@@ -0,0 +1,114 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package hazmat exposes the internal point representations and formulas of
+// filippo.io/edwards25519, for implementing custom formulas and scalar
+// multiplication loops, such as those of prime order groups built on top of
+// edwards25519.
+//
+// The types in this package have exported coordinates and perform no
+// validation: it's the caller's responsibility to only combine values that
+// represent points on the curve. Points should be converted back to
+// edwards25519.Point values with P3.ToPoint, which checks them, before being
+// exposed to the rest of a program.
+//
+// Most users should use the edwards25519 package instead.
+package hazmat
+
+import (
+	"filippo.io/edwards25519"
+	"filippo.io/edwards25519/field"
+	"filippo.io/edwards25519/internal/formulas"
+)
+
+// P1xP1 is a point in completed coordinates ((X:Z), (Y:T)), where x = X/Z and
+// y = Y/T. It is the output of the doubling and addition formulas, and must be
+// converted to P2 or P3 before further operations.
+type P1xP1 struct {
+	X, Y, Z, T field.Element
+}
+
+// P2 is a point in projective coordinates (X:Y:Z), where x = X/Z and y = Y/Z.
+// It is the input of the doubling formula.
+type P2 struct {
+	X, Y, Z field.Element
+}
+
+// P3 is a point in extended coordinates (X:Y:Z:T), where x = X/Z, y = Y/Z, and
+// xy = T/Z as in https://eprint.iacr.org/2008/522. It is the representation
+// used by edwards25519.Point.
+type P3 struct {
+	X, Y, Z, T field.Element
+}
+
+// Zero sets v to the identity element, and returns v.
+func (v *P2) Zero() *P2 {
+	v.X.Zero()
+	v.Y.One()
+	v.Z.One()
+	return v
+}
+
+// Zero sets v to the identity element, and returns v.
+func (v *P3) Zero() *P3 {
+	v.X.Zero()
+	v.Y.One()
+	v.Z.One()
+	v.T.Zero()
+	return v
+}
+
+// Conversions.
+
+// FromP1xP1 sets v = p, and returns v.
+func (v *P2) FromP1xP1(p *P1xP1) *P2 {
+	(*formulas.P2)(v).FromP1xP1((*formulas.P1xP1)(p))
+	return v
+}
+
+// FromP3 sets v = p, and returns v.
+func (v *P2) FromP3(p *P3) *P2 {
+	v.X.Set(&p.X)
+	v.Y.Set(&p.Y)
+	v.Z.Set(&p.Z)
+	return v
+}
+
+// FromP1xP1 sets v = p, and returns v.
+func (v *P3) FromP1xP1(p *P1xP1) *P3 {
+	formulas.P3FromP1xP1(&v.X, &v.Y, &v.Z, &v.T, (*formulas.P1xP1)(p))
+	return v
+}
+
+// FromP2 sets v = p, and returns v.
+func (v *P3) FromP2(p *P2) *P3 {
+	formulas.P3FromP2(&v.X, &v.Y, &v.Z, &v.T, (*formulas.P2)(p))
+	return v
+}
+
+// FromPoint sets v = p, and returns v.
+func (v *P3) FromPoint(p *edwards25519.Point) *P3 {
+	X, Y, Z, T := p.ExtendedCoordinates()
+	v.X.Set(X)
+	v.Y.Set(Y)
+	v.Z.Set(Z)
+	v.T.Set(T)
+	return v
+}
+
+// ToPoint sets p = v, and returns p.
+//
+// If v doesn't represent a valid point on the curve, ToPoint returns nil and an
+// error, and p is unchanged.
+func (v *P3) ToPoint(p *edwards25519.Point) (*edwards25519.Point, error) {
+	return p.SetExtendedCoordinates(&v.X, &v.Y, &v.Z, &v.T)
+}
+
+// Doubling.
+
+// Double sets v = 2 * p, and returns v.
+func (v *P1xP1) Double(p *P2) *P1xP1 {
+	(*formulas.P1xP1)(v).Double((*formulas.P2)(p))
+	return v
+}
@@ -0,0 +1,83 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package hazmat
+
+import (
+	"testing"
+	"testing/quick"
+
+	"filippo.io/edwards25519"
+)
+
+var quickCheckConfig = &quick.Config{MaxCountScale: 1 << 5}
+
+func randomPoint(seed [64]byte) *edwards25519.Point {
+	s, err := edwards25519.NewScalar().SetUniformBytes(seed[:])
+	if err != nil {
+		panic(err)
+	}
+	return new(edwards25519.Point).ScalarBaseMult(s)
+}
+
+func TestRoundTrip(t *testing.T) {
+	f := func(seed [64]byte) bool {
+		p := randomPoint(seed)
+		var v P3
+		q, err := v.FromPoint(p).ToPoint(new(edwards25519.Point))
+		return err == nil && q.Equal(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig); err != nil {
+		t.Error(err)
+	}
+
+	var v P3
+	v.Zero()
+	v.T.One()
+	if _, err := v.ToPoint(new(edwards25519.Point)); err == nil {
+		t.Error("ToPoint accepted an invalid point")
+	}
+}
+
+func TestDoublingChain(t *testing.T) {
+	f := func(seed [64]byte, k uint8) bool {
+		k = k%8 + 1
+		p := randomPoint(seed)
+
+		var v3 P3
+		var v2 P2
+		var v1 P1xP1
+		v2.FromP3(v3.FromPoint(p))
+		for i := uint8(0); i < k; i++ {
+			v1.Double(&v2)
+			if i%2 == 0 {
+				v2.FromP1xP1(&v1)
+			} else {
+				v2.FromP3(v3.FromP1xP1(&v1))
+			}
+		}
+		got, err := v3.FromP2(&v2).ToPoint(new(edwards25519.Point))
+		if err != nil {
+			return false
+		}
+
+		want := new(edwards25519.Point).Set(p)
+		for i := uint8(0); i < k; i++ {
+			want.Add(want, want)
+		}
+		return got.Equal(want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig); err != nil {
+		t.Error(err)
+	}
+
+	var v3 P3
+	var v2 P2
+	var v1 P1xP1
+	v1.Double(v2.Zero())
+	id, err := v3.FromP1xP1(&v1).ToPoint(new(edwards25519.Point))
+	if err != nil || id.Equal(edwards25519.NewIdentityPoint()) != 1 {
+		t.Error("2 * identity != identity")
+	}
+}
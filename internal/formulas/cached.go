@@ -0,0 +1,168 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formulas
+
+import "filippo.io/edwards25519/field"
+
+// Cached is a point in the cached representation (Y+X, Y-X, Z, 2dT), where
+// (X:Y:Z:T) are extended coordinates.
+type Cached struct {
+	YplusX, YminusX, Z, T2d field.Element
+}
+
+// AffineCached is a point in the cached representation with Z = 1, (y+x, y-x,
+// 2dxy).
+type AffineCached struct {
+	YplusX, YminusX, T2d field.Element
+}
+
+// D is a constant in the curve equation.
+var D, _ = new(field.Element).SetBytes([]byte{
+	0xa3, 0x78, 0x59, 0x13, 0xca, 0x4d, 0xeb, 0x75,
+	0xab, 0xd8, 0x41, 0x41, 0x4d, 0x0a, 0x70, 0x00,
+	0x98, 0xe8, 0x79, 0x77, 0x79, 0x40, 0xc7, 0x8c,
+	0x73, 0xfe, 0x6f, 0x2b, 0xee, 0x6c, 0x03, 0x52})
+
+// D2 is 2 * D.
+var D2 = new(field.Element).Add(D, D)
+
+// FromP3 sets v to the extended coordinates (x:y:z:t), and returns v.
+func (v *Cached) FromP3(x, y, z, t *field.Element) *Cached {
+	v.YplusX.Add(y, x)
+	v.YminusX.Subtract(y, x)
+	v.Z.Set(z)
+	v.T2d.Multiply(t, D2)
+	return v
+}
+
+// FromP3ZInv sets v to the extended coordinates (x:y:z:t), given the inverse
+// of z, and returns v.
+func (v *AffineCached) FromP3ZInv(x, y, t, invZ *field.Element) *AffineCached {
+	v.YplusX.Add(y, x)
+	v.YminusX.Subtract(y, x)
+	v.T2d.Multiply(t, D2)
+
+	v.YplusX.Multiply(&v.YplusX, invZ)
+	v.YminusX.Multiply(&v.YminusX, invZ)
+	v.T2d.Multiply(&v.T2d, invZ)
+	return v
+}
+
+// (Re)addition and subtraction.
+
+// Add sets v = (x:y:z:t) + q, and returns v.
+func (v *P1xP1) Add(x, y, z, t *field.Element, q *Cached) *P1xP1 {
+	var YplusX, YminusX, PP, MM, TT2d, ZZ2 field.Element
+
+	YplusX.Add(y, x)
+	YminusX.Subtract(y, x)
+
+	PP.Multiply(&YplusX, &q.YplusX)
+	MM.Multiply(&YminusX, &q.YminusX)
+	TT2d.Multiply(t, &q.T2d)
+	ZZ2.Multiply(z, &q.Z)
+
+	ZZ2.Add(&ZZ2, &ZZ2)
+
+	v.X.Subtract(&PP, &MM)
+	v.Y.Add(&PP, &MM)
+	v.Z.Add(&ZZ2, &TT2d)
+	v.T.Subtract(&ZZ2, &TT2d)
+	return v
+}
+
+// Sub sets v = (x:y:z:t) - q, and returns v.
+func (v *P1xP1) Sub(x, y, z, t *field.Element, q *Cached) *P1xP1 {
+	var YplusX, YminusX, PP, MM, TT2d, ZZ2 field.Element
+
+	YplusX.Add(y, x)
+	YminusX.Subtract(y, x)
+
+	PP.Multiply(&YplusX, &q.YminusX) // flipped sign
+	MM.Multiply(&YminusX, &q.YplusX) // flipped sign
+	TT2d.Multiply(t, &q.T2d)
+	ZZ2.Multiply(z, &q.Z)
+
+	ZZ2.Add(&ZZ2, &ZZ2)
+
+	v.X.Subtract(&PP, &MM)
+	v.Y.Add(&PP, &MM)
+	v.Z.Subtract(&ZZ2, &TT2d) // flipped sign
+	v.T.Add(&ZZ2, &TT2d)      // flipped sign
+	return v
+}
+
+// AddAffine sets v = (x:y:z:t) + q, and returns v.
+func (v *P1xP1) AddAffine(x, y, z, t *field.Element, q *AffineCached) *P1xP1 {
+	var YplusX, YminusX, PP, MM, TT2d, Z2 field.Element
+
+	YplusX.Add(y, x)
+	YminusX.Subtract(y, x)
+
+	PP.Multiply(&YplusX, &q.YplusX)
+	MM.Multiply(&YminusX, &q.YminusX)
+	TT2d.Multiply(t, &q.T2d)
+
+	Z2.Add(z, z)
+
+	v.X.Subtract(&PP, &MM)
+	v.Y.Add(&PP, &MM)
+	v.Z.Add(&Z2, &TT2d)
+	v.T.Subtract(&Z2, &TT2d)
+	return v
+}
+
+// SubAffine sets v = (x:y:z:t) - q, and returns v.
+func (v *P1xP1) SubAffine(x, y, z, t *field.Element, q *AffineCached) *P1xP1 {
+	var YplusX, YminusX, PP, MM, TT2d, Z2 field.Element
+
+	YplusX.Add(y, x)
+	YminusX.Subtract(y, x)
+
+	PP.Multiply(&YplusX, &q.YminusX) // flipped sign
+	MM.Multiply(&YminusX, &q.YplusX) // flipped sign
+	TT2d.Multiply(t, &q.T2d)
+
+	Z2.Add(z, z)
+
+	v.X.Subtract(&PP, &MM)
+	v.Y.Add(&PP, &MM)
+	v.Z.Subtract(&Z2, &TT2d) // flipped sign
+	v.T.Add(&Z2, &TT2d)      // flipped sign
+	return v
+}
+
+// Constant-time operations.
+
+// Select sets v to a if cond == 1 and to b if cond == 0.
+func (v *Cached) Select(a, b *Cached, cond int) *Cached {
+	v.YplusX.Select(&a.YplusX, &b.YplusX, cond)
+	v.YminusX.Select(&a.YminusX, &b.YminusX, cond)
+	v.Z.Select(&a.Z, &b.Z, cond)
+	v.T2d.Select(&a.T2d, &b.T2d, cond)
+	return v
+}
+
+// Select sets v to a if cond == 1 and to b if cond == 0.
+func (v *AffineCached) Select(a, b *AffineCached, cond int) *AffineCached {
+	v.YplusX.Select(&a.YplusX, &b.YplusX, cond)
+	v.YminusX.Select(&a.YminusX, &b.YminusX, cond)
+	v.T2d.Select(&a.T2d, &b.T2d, cond)
+	return v
+}
+
+// CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0.
+func (v *Cached) CondNeg(cond int) *Cached {
+	v.YplusX.Swap(&v.YminusX, cond)
+	v.T2d.Select(new(field.Element).Negate(&v.T2d), &v.T2d, cond)
+	return v
+}
+
+// CondNeg negates v if cond == 1 and leaves it unchanged if cond == 0.
+func (v *AffineCached) CondNeg(cond int) *AffineCached {
+	v.YplusX.Swap(&v.YminusX, cond)
+	v.T2d.Select(new(field.Element).Negate(&v.T2d), &v.T2d, cond)
+	return v
+}
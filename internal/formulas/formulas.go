@@ -0,0 +1,72 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package formulas implements the point representations and formulas shared
+// by the edwards25519 and hazmat packages.
+//
+// The edwards25519 and hazmat packages define their own types with the same
+// fields, and convert pointers to them to and from the types in this package.
+// Points in extended coordinates are passed as their four coordinates, since
+// edwards25519.Point has unexported fields.
+package formulas
+
+import "filippo.io/edwards25519/field"
+
+// P1xP1 is a point in completed coordinates ((X:Z), (Y:T)), where x = X/Z and
+// y = Y/T.
+type P1xP1 struct {
+	X, Y, Z, T field.Element
+}
+
+// P2 is a point in projective coordinates (X:Y:Z), where x = X/Z and y = Y/Z.
+type P2 struct {
+	X, Y, Z field.Element
+}
+
+// Conversions.
+
+// FromP1xP1 sets v = p, and returns v.
+func (v *P2) FromP1xP1(p *P1xP1) *P2 {
+	v.X.Multiply(&p.X, &p.T)
+	v.Y.Multiply(&p.Y, &p.Z)
+	v.Z.Multiply(&p.Z, &p.T)
+	return v
+}
+
+// P3FromP1xP1 sets the extended coordinates (x:y:z:t) to p.
+func P3FromP1xP1(x, y, z, t *field.Element, p *P1xP1) {
+	x.Multiply(&p.X, &p.T)
+	y.Multiply(&p.Y, &p.Z)
+	z.Multiply(&p.Z, &p.T)
+	t.Multiply(&p.X, &p.Y)
+}
+
+// P3FromP2 sets the extended coordinates (x:y:z:t) to p.
+func P3FromP2(x, y, z, t *field.Element, p *P2) {
+	x.Multiply(&p.X, &p.Z)
+	y.Multiply(&p.Y, &p.Z)
+	z.Square(&p.Z)
+	t.Multiply(&p.X, &p.Y)
+}
+
+// Doubling.
+
+// Double sets v = 2 * p, and returns v.
+func (v *P1xP1) Double(p *P2) *P1xP1 {
+	var XX, YY, ZZ2, XplusYsq field.Element
+
+	XX.Square(&p.X)
+	YY.Square(&p.Y)
+	ZZ2.Square(&p.Z)
+	ZZ2.Add(&ZZ2, &ZZ2)
+	XplusYsq.Add(&p.X, &p.Y)
+	XplusYsq.Square(&XplusYsq)
+
+	v.Y.Add(&YY, &XX)
+	v.Z.Subtract(&YY, &XX)
+
+	v.X.Subtract(&XplusYsq, &v.Y)
+	v.T.Subtract(&ZZ2, &v.Z)
+	return v
+}
@@ -0,0 +1,274 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package safegcd implements the modulus-independent parts of modular
+// inversion with the safegcd algorithm from "Fast constant-time gcd
+// computation and modular inversion" by Bernstein and Yang,
+// https://eprint.iacr.org/2019/266, following the design of libsecp256k1's
+// modinv64, described in detail at
+// https://github.com/bitcoin-core/secp256k1/blob/master/doc/safegcd_implementation.md.
+//
+// The constant-time algorithm runs 590 divsteps, which are sufficient for any
+// 256-bit input, in ten batches of 59. Each batch only looks at the bottom 64
+// bits of f and g to compute a 2x2 transition matrix, which is then applied to
+// the full f, g, d, and e, represented as five signed 62-bit limbs. The
+// variable-time algorithm stops as soon as g is zero, and performs multiple
+// divsteps at a time.
+//
+// The field and edwards25519 packages provide the modulus, the update of d
+// and e modulo it, which benefits from knowing its limbs, and the conversions
+// from and to their element representations. See Inverter.
+package safegcd
+
+import "math/bits"
+
+// A Signed62 is a signed integer
+//
+//	v[0] + v[1] * 2^62 + v[2] * 2^124 + v[3] * 2^186 + v[4] * 2^248
+//
+// where all limbs but the top one are normally in [0, 2^62).
+type Signed62 [5]int64
+
+// Mask62 is the mask of the bottom 62 bits.
+const Mask62 = 1<<62 - 1
+
+// An Inverter computes 1 / g mod m, where m is odd and less than 2^256, and g
+// is in [0, m).
+//
+// The caller must update D and E modulo m after each call to Next, as it
+// knows the limbs of m, like this:
+//
+//	var inv safegcd.Inverter
+//	inv.Init(g, &m)
+//	for inv.Next() {
+//		updateDE(&inv.D, &inv.E, &inv.T) // D, E = T * [D, E] / 2^62 mod m
+//	}
+//	d := inv.Result()
+//
+// updateDE must keep D and E in (-2m, m).
+type Inverter struct {
+	// D and E start as 0 and 1, and end with D = ±1 / g.
+	D, E Signed62
+	// T is the transition matrix [u, v, q, r] of the last batch of divsteps,
+	// scaled by 2^62.
+	T [4]int64
+
+	m       *Signed62
+	f, g    Signed62
+	zeta    int64 // -(delta + 1/2) or, if varTime, eta = -delta
+	batches int
+	varTime bool
+}
+
+// Init sets up inv to compute 1 / g mod m in constant time, running 590
+// divsteps, which are sufficient for any 256-bit input, in ten batches of 59.
+func (inv *Inverter) Init(g Signed62, m *Signed62) {
+	// Start with d = 0, e = 1, f = m, and zeta = -1, which is
+	// -(delta + 1/2) for delta = 1/2.
+	*inv = Inverter{m: m, f: *m, g: g, zeta: -1}
+	inv.E[0] = 1
+}
+
+// InitVarTime works like Init, but sets up inv to run in variable time,
+// stopping as soon as g is zero.
+func (inv *Inverter) InitVarTime(g Signed62, m *Signed62) {
+	*inv = Inverter{m: m, f: *m, g: g, zeta: -1, varTime: true} // eta = -delta, with delta = 1
+	inv.E[0] = 1
+}
+
+// Next runs a batch of divsteps, sets T, and reports whether D and E must be
+// updated. Once it returns false, the computation is complete.
+func (inv *Inverter) Next() bool {
+	if inv.varTime {
+		if inv.batches > 0 && inv.g == (Signed62{}) {
+			return false
+		}
+		inv.zeta = divsteps62VarTime(inv.zeta, uint64(inv.f[0]), uint64(inv.g[0]), &inv.T)
+	} else {
+		if inv.batches == 10 {
+			return false
+		}
+		inv.zeta = divsteps59(inv.zeta, uint64(inv.f[0]), uint64(inv.g[0]), &inv.T)
+	}
+	updateFG(&inv.f, &inv.g, &inv.T)
+	inv.batches++
+	return true
+}
+
+// Result returns 1 / g mod m, in [0, m). If g was zero, it returns 0.
+func (inv *Inverter) Result() Signed62 {
+	// Now g is zero and f is ±1 (unless g was zero), and d is ±1 / g.
+	d := inv.D
+	d.normalize(inv.f[4], inv.m)
+	return d
+}
+
+// divsteps62VarTime performs 62 divsteps on the bottom 64 bits of f and g,
+// starting from eta, and returns the new eta. It sets t like divsteps59.
+//
+// Instead of one divstep at a time, it skips all the trailing zeros of g at
+// once, and then cancels out multiple bits of g with a single addition.
+func divsteps62VarTime(eta int64, f0, g0 uint64, t *[4]int64) int64 {
+	u, v, q, r := uint64(1), uint64(0), uint64(0), uint64(1)
+	f, g := f0, g0
+	i := 62
+	for {
+		// Halve g as many times as it has trailing zeros, up to i, using a
+		// sentinel bit.
+		zeros := bits.TrailingZeros64(g | (^uint64(0) << i))
+		g >>= zeros
+		u <<= zeros
+		v <<= zeros
+		eta -= int64(zeros)
+		i -= zeros
+		if i == 0 {
+			break
+		}
+
+		// Both f and g are now odd. If eta is negative, negate it and replace
+		// f, g with g, -f.
+		swapped := eta < 0
+		if swapped {
+			eta = -eta
+			f, g = g, -f
+			u, q = q, -u
+			v, r = r, -v
+		}
+
+		// Add to g the multiple of f that cancels its bottom bits, but no
+		// more than i of them, and no more than eta + 1, after which eta
+		// would become negative again.
+		limit := int(eta) + 1
+		if limit > i {
+			limit = i
+		}
+		var w, m uint64
+		if swapped {
+			// Cancel up to six bits, adding -g / f mod 2^6 times f, where
+			// -1 / f = f * (f² - 2) mod 2^6 by one Newton iteration.
+			m = (^uint64(0) >> (64 - limit)) & 63
+			w = (f * g * (f*f - 2)) & m
+		} else {
+			// Cancel up to four bits, as eta tends to be smaller here.
+			m = (^uint64(0) >> (64 - limit)) & 15
+			w = f + (((f + 1) & 4) << 1) // 1 / f mod 2^4
+			w = (-w * g) & m
+		}
+		g += f * w
+		q += u * w
+		r += v * w
+	}
+	t[0], t[1], t[2], t[3] = int64(u), int64(v), int64(q), int64(r)
+	return eta
+}
+
+// divsteps59 performs 59 divsteps on the bottom 64 bits of f and g, starting
+// from zeta, and returns the new zeta. It sets t to the transition matrix
+// [u, v, q, r] scaled by 2^62, such that after 59 divsteps
+//
+//	f' = (u * f + v * g) / 2^62
+//	g' = (q * f + r * g) / 2^62
+//
+// Execution time doesn't depend on the inputs.
+func divsteps59(zeta int64, f0, g0 uint64, t *[4]int64) int64 {
+	// u, v, q, r start as the identity matrix times 8, to end up scaled by
+	// 2^(3 + 59). They are signed values in [-2^62, 2^62] represented as
+	// unsigned mod 2^64, to allow left shifting.
+	u, v, q, r := uint64(8), uint64(0), uint64(0), uint64(8)
+	f, g := f0, g0
+	for i := 3; i < 62; i++ {
+		// mask1 is set if zeta < 0, and mask2 if g is odd.
+		mask1 := uint64(zeta >> 63)
+		mask2 := -(g & 1)
+		// Conditionally add the conditionally negated f, u, v to g, q, r.
+		x := (f ^ mask1) - mask1
+		y := (u ^ mask1) - mask1
+		z := (v ^ mask1) - mask1
+		g += x & mask2
+		q += y & mask2
+		r += z & mask2
+		// If zeta < 0 and g was odd, swap the roles of f and g by setting
+		// zeta to -zeta - 2 and adding the new g, q, r to f, u, v.
+		// Otherwise, set zeta to zeta - 1.
+		mask1 &= mask2
+		zeta = (zeta ^ int64(mask1)) - 1
+		f += g & mask1
+		u += q & mask1
+		v += r & mask1
+		// g is now even, so halve it, and scale u and v to compensate.
+		g >>= 1
+		u <<= 1
+		v <<= 1
+	}
+	t[0], t[1], t[2], t[3] = int64(u), int64(v), int64(q), int64(r)
+	return zeta
+}
+
+// updateFG sets f, g = t * [f, g] / 2^62, which is exact.
+func updateFG(f, g *Signed62, t *[4]int64) {
+	u, v, q, r := t[0], t[1], t[2], t[3]
+	var cf, cg Int128
+	cf = cf.AddMul(u, f[0]).AddMul(v, g[0]).Shr62()
+	cg = cg.AddMul(q, f[0]).AddMul(r, g[0]).Shr62()
+	for i := 1; i < 5; i++ {
+		cf = cf.AddMul(u, f[i]).AddMul(v, g[i])
+		cg = cg.AddMul(q, f[i]).AddMul(r, g[i])
+		f[i-1] = int64(cf.Lo & Mask62)
+		g[i-1] = int64(cg.Lo & Mask62)
+		cf, cg = cf.Shr62(), cg.Shr62()
+	}
+	f[4] = int64(cf.Lo)
+	g[4] = int64(cg.Lo)
+}
+
+// normalize brings x from (-2m, m) to [0, m), negating it if sign is
+// negative.
+func (x *Signed62) normalize(sign int64, m *Signed62) {
+	// Add m if x is negative, and then negate it if requested, bringing it to
+	// (-m, m). Then add m again if it's still negative.
+	x.condAdd(m, x[4]>>63)
+	neg := sign >> 63
+	for i := range x {
+		x[i] = (x[i] ^ neg) - neg
+	}
+	x.carry()
+	x.condAdd(m, x[4]>>63)
+	x.carry()
+}
+
+func (x *Signed62) condAdd(m *Signed62, mask int64) {
+	for i := range x {
+		x[i] += m[i] & mask
+	}
+}
+
+// carry brings the bottom four limbs of x to [0, 2^62).
+func (x *Signed62) carry() {
+	for i := 0; i < 4; i++ {
+		x[i+1] += x[i] >> 62
+		x[i] &= Mask62
+	}
+}
+
+// An Int128 is a two's complement signed 128-bit integer.
+type Int128 struct {
+	Lo, Hi uint64
+}
+
+// AddMul returns x + a * b.
+func (x Int128) AddMul(a, b int64) Int128 {
+	hi, lo := bits.Mul64(uint64(a), uint64(b))
+	// Adjust the unsigned product for the signs of a and b.
+	hi -= uint64(a>>63) & uint64(b)
+	hi -= uint64(b>>63) & uint64(a)
+	var c uint64
+	lo, c = bits.Add64(lo, x.Lo, 0)
+	hi, _ = bits.Add64(hi, x.Hi, c)
+	return Int128{lo, hi}
+}
+
+// Shr62 returns x >> 62, with sign extension.
+func (x Int128) Shr62() Int128 {
+	return Int128{x.Lo>>62 | x.Hi<<2, uint64(int64(x.Hi) >> 62)}
+}
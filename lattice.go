@@ -0,0 +1,338 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"sync"
+)
+
+// This file implements the verification speedup of "Optimized Lattice Basis
+// Reduction In Dimension 2, and Fast Schnorr and EdDSA Signature Verification"
+// by Thomas Pornin, https://eprint.iacr.org/2020/454.
+//
+// Given the challenge k, lattice basis reduction finds v0 and v1 of about 127
+// bits each such that v0 = v1 * k mod l. The cofactored verification equation
+//
+//	[8]([S]B - R - [k]A) = 0
+//
+// is then equivalent to
+//
+//	[8]([v1 * S]B - [v1]R - [v0]A) = 0
+//
+// since v1 is invertible modulo l, and [8][v1 * k]A = [8][v0]A because the
+// difference is a multiple of the order of the group, 8 * l. The full-size
+// scalar v1 * S mod l is applied to the fixed generator, and is split into two
+// halves for B and 2^128 * B, which both have precomputed tables, so that all
+// four scalars are half-size and the multiplication needs half the doublings.
+
+// varTimeCofactoredCheck reports whether [8]([S]B - R - [k]A) is the identity.
+func varTimeCofactoredCheck(S, k *Scalar, R, A *Point) bool {
+	v0, v1, ok := latticeReduce(k)
+	if !ok {
+		var check Point
+		minusK := new(Scalar).Negate(k)
+		check.VarTimeDoubleScalarBaseMult(minusK, A, S)
+		check.Subtract(&check, R)
+		check.MultByCofactor(&check)
+		return check.EqualVarTime(identity) == 1
+	}
+
+	// The terms are [|v1|](∓R) and [|v0|](∓A), with the sign opposite to the
+	// sign of v1 and v0, respectively.
+	var minusR, minusA Point
+	pointR, pointA := R, A
+	if !v1.isNegative() {
+		pointR = minusR.Negate(R)
+	}
+	if !v0.isNegative() {
+		pointA = minusA.Negate(A)
+	}
+	var scR, scA Scalar
+	scR.setShortBytes(v1.absBytes())
+	scA.setShortBytes(v0.absBytes())
+
+	// s = v1 * S mod l = sLo + 2^128 * sHi
+	var s, sLo, sHi Scalar
+	s.Multiply(&scR, S)
+	if v1.isNegative() {
+		s.Negate(&s)
+	}
+	var buf [32]byte
+	sBytes := s.bytes(&buf)
+	sLo.setShortBytes(sBytes[:16])
+	sHi.setShortBytes(sBytes[16:])
+
+	var tableR, tableA nafLookupTable5
+	tableR.FromP3(pointR)
+	tableA.FromP3(pointA)
+	nafs := [4][256]int8{scR.nonAdjacentForm(5), scA.nonAdjacentForm(5),
+		sLo.nonAdjacentForm(8), sHi.nonAdjacentForm(8)}
+	bTables := [2]*nafLookupTable8{basepointNafTable(), basepoint128NafTable()}
+
+	// Proceed as in varTimeDoubleScalarBaseMult. All the scalars are shorter
+	// than 130 bits, including the NAF carry.
+	multiple := &projCached{}
+	multB := &affineCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+	tmp2.Zero()
+	var check Point
+	for i := 129; i >= 0; i-- {
+		tmp1.Double(tmp2)
+
+		for j, table := range [2]*nafLookupTable5{&tableR, &tableA} {
+			if d := nafs[j][i]; d > 0 {
+				check.fromP1xP1(tmp1)
+				table.SelectInto(multiple, d)
+				tmp1.Add(&check, multiple)
+			} else if d < 0 {
+				check.fromP1xP1(tmp1)
+				table.SelectInto(multiple, -d)
+				tmp1.Sub(&check, multiple)
+			}
+		}
+		for j, table := range bTables {
+			if d := nafs[2+j][i]; d > 0 {
+				check.fromP1xP1(tmp1)
+				table.SelectInto(multB, d)
+				tmp1.AddAffine(&check, multB)
+			} else if d < 0 {
+				check.fromP1xP1(tmp1)
+				table.SelectInto(multB, -d)
+				tmp1.SubAffine(&check, multB)
+			}
+		}
+
+		tmp2.FromP1xP1(tmp1)
+	}
+	check.fromP2(tmp2)
+	check.MultByCofactor(&check)
+	return check.EqualVarTime(identity) == 1
+}
+
+// basepoint128NafTable is the nafLookupTable8 for 2^128 * B. It is
+// precomputed the first time it's used.
+func basepoint128NafTable() *nafLookupTable8 {
+	basepoint128NafTablePrecomp.initOnce.Do(func() {
+		p := NewGeneratorPoint()
+		for i := 0; i < 128; i++ {
+			p.Double(p)
+		}
+		basepoint128NafTablePrecomp.table.FromP3(p)
+	})
+	return &basepoint128NafTablePrecomp.table
+}
+
+var basepoint128NafTablePrecomp struct {
+	table    nafLookupTable8
+	initOnce sync.Once
+}
+
+// latticeReduce returns v0 and v1 such that v0 = v1 * k mod l, with v1 != 0
+// and |v0|, |v1| < 2^127, using Algorithm 4 of Pornin's paper. If ok is false,
+// the reduction didn't converge, which is not expected to happen for any k.
+//
+// Execution time depends on k.
+func latticeReduce(k *Scalar) (v0, v1 int128, ok bool) {
+	var kl [4]uint64
+	kb := k.Bytes()
+	for i := range kl {
+		kl[i] = binary.LittleEndian.Uint64(kb[i*8:])
+	}
+
+	// The basis starts as u = (l, 0), v = (k, 1), with squared norms
+	// Nu = l² and Nv = k² + 1, and inner product p = l * k. The vectors are
+	// only tracked modulo 2^128, which is exact for the short result.
+	var u0, u1 int128
+	u0 = int128{lLimbs[0], lLimbs[1]}
+	v0 = int128{kl[0], kl[1]}
+	v1 = int128{1, 0}
+	var nu, nv, p, t, w int512
+	nu.mul256(&lLimbs, &lLimbs)
+	nv.mul256(&kl, &kl)
+	nv.add(&nv, &int512{1})
+	p.mul256(&lLimbs, &kl)
+
+	for i := 0; i < 1000; i++ {
+		if nu.less(&nv) {
+			u0, v0 = v0, u0
+			u1, v1 = v1, u1
+			nu, nv = nv, nu
+		}
+		if nv.bitLen() <= 254 {
+			return v0, v1, true
+		}
+
+		var s uint
+		if lp, lv := p.bitLen(), nv.bitLen(); lp > lv {
+			s = uint(lp - lv)
+		}
+		// Nu = Nu + 2^2s * Nv ∓ 2^(s+1) * p = Nu + 2^s * (2^s * Nv ∓ 2 * p)
+		t.shl(&nv, s)
+		if !p.isNegative() {
+			// u = u - 2^s * v, p = p - 2^s * Nv
+			u0.sub(&u0, v0.shl(s))
+			u1.sub(&u1, v1.shl(s))
+			w.sub(&t, &p)
+			w.sub(&w, &p)
+			p.sub(&p, &t)
+		} else {
+			// u = u + 2^s * v, p = p + 2^s * Nv
+			u0.add(&u0, v0.shl(s))
+			u1.add(&u1, v1.shl(s))
+			w.add(&t, &p)
+			w.add(&w, &p)
+			p.add(&p, &t)
+		}
+		w.shl(&w, s)
+		nu.add(&nu, &w)
+	}
+	return int128{}, int128{}, false
+}
+
+// lLimbs is l = 2^252 + 27742317777372353535851937790883648493 as
+// little-endian 64-bit limbs.
+var lLimbs = [4]uint64{0x5812631a5cf5d3ed, 0x14def9dea2f79cd6, 0, 0x1000000000000000}
+
+// An int128 is a two's complement signed 128-bit integer, as little-endian
+// 64-bit limbs.
+type int128 [2]uint64
+
+func (x *int128) isNegative() bool {
+	return int64(x[1]) < 0
+}
+
+func (x *int128) add(a, b *int128) {
+	var c uint64
+	x[0], c = bits.Add64(a[0], b[0], 0)
+	x[1], _ = bits.Add64(a[1], b[1], c)
+}
+
+func (x *int128) sub(a, b *int128) {
+	var c uint64
+	x[0], c = bits.Sub64(a[0], b[0], 0)
+	x[1], _ = bits.Sub64(a[1], b[1], c)
+}
+
+// shl returns x * 2^s mod 2^128.
+func (x *int128) shl(s uint) *int128 {
+	switch {
+	case s >= 128:
+		return &int128{}
+	case s >= 64:
+		return &int128{0, x[0] << (s - 64)}
+	case s == 0:
+		return &int128{x[0], x[1]}
+	default:
+		return &int128{x[0] << s, x[1]<<s | x[0]>>(64-s)}
+	}
+}
+
+// absBytes returns the 16-byte little-endian encoding of |x|.
+func (x *int128) absBytes() []byte {
+	a := *x
+	if a.isNegative() {
+		a.sub(&int128{}, &a)
+	}
+	var out [16]byte
+	for i := 0; i < 8; i++ {
+		out[i] = byte(a[0] >> (8 * i))
+		out[8+i] = byte(a[1] >> (8 * i))
+	}
+	return out[:]
+}
+
+// An int512 is a two's complement signed 512-bit integer, as little-endian
+// 64-bit limbs.
+type int512 [8]uint64
+
+func (x *int512) isNegative() bool {
+	return int64(x[7]) < 0
+}
+
+// mul256 sets x = a * b.
+func (x *int512) mul256(a, b *[4]uint64) {
+	*x = int512{}
+	for i := range a {
+		var carry uint64
+		for j := range b {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, x[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			x[i+j], carry = lo, hi
+		}
+		x[i+4] = carry
+	}
+}
+
+func (x *int512) add(a, b *int512) {
+	var c uint64
+	x[0], c = bits.Add64(a[0], b[0], 0)
+	x[1], c = bits.Add64(a[1], b[1], c)
+	x[2], c = bits.Add64(a[2], b[2], c)
+	x[3], c = bits.Add64(a[3], b[3], c)
+	x[4], c = bits.Add64(a[4], b[4], c)
+	x[5], c = bits.Add64(a[5], b[5], c)
+	x[6], c = bits.Add64(a[6], b[6], c)
+	x[7], _ = bits.Add64(a[7], b[7], c)
+}
+
+func (x *int512) sub(a, b *int512) {
+	var c uint64
+	x[0], c = bits.Sub64(a[0], b[0], 0)
+	x[1], c = bits.Sub64(a[1], b[1], c)
+	x[2], c = bits.Sub64(a[2], b[2], c)
+	x[3], c = bits.Sub64(a[3], b[3], c)
+	x[4], c = bits.Sub64(a[4], b[4], c)
+	x[5], c = bits.Sub64(a[5], b[5], c)
+	x[6], c = bits.Sub64(a[6], b[6], c)
+	x[7], _ = bits.Sub64(a[7], b[7], c)
+}
+
+// shl sets x = a * 2^s mod 2^512.
+func (x *int512) shl(a *int512, s uint) {
+	limbs, s := int(s/64), s%64
+	for i := len(x) - 1; i >= 0; i-- {
+		j := i - limbs
+		var hi, lo uint64
+		if j >= 0 {
+			hi = a[j]
+		}
+		if j >= 1 && s != 0 {
+			lo = a[j-1] >> (64 - s)
+		}
+		x[i] = hi<<s | lo
+	}
+}
+
+// bitLen returns the length of x in bits, excluding the sign bit. For negative
+// values, it's the length of -x - 1.
+func (x *int512) bitLen() int {
+	var mask uint64
+	if x.isNegative() {
+		mask = ^uint64(0)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		if w := x[i] ^ mask; w != 0 {
+			return i*64 + bits.Len64(w)
+		}
+	}
+	return 0
+}
+
+// less reports whether x < y, for non-negative x and y.
+func (x *int512) less(y *int512) bool {
+	for i := len(x) - 1; i >= 0; i-- {
+		if x[i] != y[i] {
+			return x[i] < y[i]
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+)
+
+func int128ToBig(x int128) *big.Int {
+	abs := new(big.Int).SetBytes(swapEndianness(x.absBytes()))
+	if x.isNegative() {
+		abs.Neg(abs)
+	}
+	return abs
+}
+
+func TestLatticeReduce(t *testing.T) {
+	l, _ := new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+	bound := new(big.Int).Lsh(big.NewInt(1), 127)
+	f := func(k Scalar) bool {
+		v0, v1, ok := latticeReduce(&k)
+		if !ok {
+			t.Logf("reduction did not converge for %x", k.Bytes())
+			return false
+		}
+		b0, b1 := int128ToBig(v0), int128ToBig(v1)
+		if b1.Sign() == 0 || new(big.Int).Abs(b0).Cmp(bound) >= 0 || new(big.Int).Abs(b1).Cmp(bound) >= 0 {
+			return false
+		}
+		// v0 = v1 * k mod l
+		kk := bigIntFromLittleEndianBytes(k.Bytes())
+		check := new(big.Int).Mul(b1, kk)
+		check.Sub(check, b0)
+		return check.Mod(check, l).Sign() == 0
+	}
+	for _, k := range []*Scalar{NewScalar(), scOne, scMinusOne, dalekScalar} {
+		if !f(*k) {
+			t.Errorf("failed for %x", k.Bytes())
+		}
+	}
+	if err := quick.Check(f, quickCheckConfig(64)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestVarTimeCofactoredCheck(t *testing.T) {
+	f := func(S, k, r Scalar, torsion uint8) bool {
+		A := new(Point).ScalarBaseMult(dalekScalar)
+		A.Add(A, SmallOrderPoints()[torsion%8])
+		// R = [S]B - [k]A plus a small order component.
+		R := new(Point).VarTimeDoubleScalarBaseMult(new(Scalar).Negate(&k), A, &S)
+		R.Add(R, SmallOrderPoints()[(torsion/8)%8])
+		if !varTimeCofactoredCheck(&S, &k, R, A) {
+			return false
+		}
+		R.Add(R, new(Point).ScalarBaseMult(&r))
+		return varTimeCofactoredCheck(&S, &k, R, A) == (r == Scalar{})
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func BenchmarkLatticeReduce(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		latticeReduce(dalekScalar)
+	}
+}
@@ -0,0 +1,304 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+// An MSM accumulates the terms of a multi-scalar multiplication one at a time,
+// for example while parsing a proof transcript, and computes their sum with a
+// single call to Result or VarTimeResult.
+//
+// Unlike Batch, MSM copies the values passed to Add and AddBase, so the
+// caller can reuse them immediately.
+//
+// An MSM owns the temporary buffers of its computations, like an MSMScratch.
+// Once an MSM has grown to fit the largest equation, reusing it with Reset
+// doesn't allocate, so a single MSM can serve as the workspace of a verifier
+// processing a stream of equations.
+//
+// The zero value is an empty MSM ready to use.
+type MSM struct {
+	scalars []Scalar
+	points  []Point
+
+	// base is the sum of the scalars passed to AddBase.
+	base    Scalar
+	hasBase bool
+
+	scratch MSMScratch
+}
+
+// NewMSM returns a new empty MSM.
+func NewMSM() *MSM {
+	return &MSM{}
+}
+
+// Add adds the term s * p.
+func (m *MSM) Add(s *Scalar, p *Point) {
+	checkInitialized(p)
+	m.scalars = append(m.scalars, *s)
+	m.points = append(m.points, Point{})
+	m.points[len(m.points)-1].Set(p)
+}
+
+// AddBase adds the term s * B, where B is the canonical generator. All such
+// terms are merged into one.
+func (m *MSM) AddBase(s *Scalar) {
+	m.base.Add(&m.base, s)
+	m.hasBase = true
+}
+
+// Len returns the number of terms added with Add, not counting the generator
+// terms added with AddBase.
+func (m *MSM) Len() int {
+	return len(m.points)
+}
+
+// Reset removes all terms, retaining the allocated memory for reuse by
+// future terms and computations.
+func (m *MSM) Reset() {
+	for i := range m.scalars {
+		m.scalars[i].Reset()
+	}
+	m.scalars = m.scalars[:0]
+	m.points = m.points[:0]
+	m.base.Reset()
+	m.hasBase = false
+}
+
+// Result sets v to the sum of all terms, and returns v. The computation is
+// done in constant time, and execution time depends only on the number of
+// terms and on whether AddBase was called.
+func (m *MSM) Result(v *Point) *Point {
+	terms := &msmTerms{values: true, scalarValues: m.scalars, pointValues: m.points}
+	if !m.hasBase {
+		return v.multiScalarMult(terms, &m.scratch)
+	}
+	base := new(Point).ScalarBaseMult(&m.base)
+	v.multiScalarMult(terms, &m.scratch)
+	return v.Add(v, base)
+}
+
+// VarTimeResult sets v to the sum of all terms, and returns v.
+//
+// Execution time depends on the inputs.
+func (m *MSM) VarTimeResult(v *Point) *Point {
+	terms := &msmTerms{values: true, scalarValues: m.scalars, pointValues: m.points}
+	var b *Scalar
+	if m.hasBase {
+		b = &m.base
+	}
+	return v.varTimeMultiScalarMult(terms, b, &m.scratch)
+}
+
+// msmTerms are the inputs of a multi-scalar multiplication, either as slices
+// of pointers or as slices of values, so that the implementations can be
+// shared without converting between the two.
+type msmTerms struct {
+	scalars []*Scalar
+	points  []*Point
+
+	// If values is true, the terms are in scalarValues and pointValues.
+	values       bool
+	scalarValues []Scalar
+	pointValues  []Point
+}
+
+func (t *msmTerms) len() int {
+	if t.values {
+		return len(t.pointValues)
+	}
+	return len(t.points)
+}
+
+func (t *msmTerms) scalar(i int) *Scalar {
+	if t.values {
+		return &t.scalarValues[i]
+	}
+	return t.scalars[i]
+}
+
+func (t *msmTerms) point(i int) *Point {
+	if t.values {
+		return &t.pointValues[i]
+	}
+	return t.points[i]
+}
+
+// An MSMScratch holds the temporary buffers of a multi-scalar multiplication,
+// so that they can be reused across calls to MultiScalarMultWithScratch and
+// VarTimeMultiScalarMultWithScratch. Once the buffers have grown to fit the
+// largest input, repeated multiplications don't allocate.
+//
+// The zero value is an empty MSMScratch ready to use. An MSMScratch must not
+// be used by multiple multiplications concurrently.
+type MSMScratch struct {
+	projBuf      []projLookupTable
+	radix16Buf   [][64]int8
+	nafBuf       []nafLookupTable5
+	nafDigitsBuf [][256]int8
+	cachedBuf    []projCached
+	signedBuf    []int32
+	bucketBuf    []Point
+}
+
+// MultiScalarMultWithScratch sets v = sum(scalars[i] * points[i]), and
+// returns v. It works like MultiScalarMult, but uses the buffers in scratch.
+//
+// Execution time depends only on the lengths of the two slices, which must match.
+func (v *Point) MultiScalarMultWithScratch(scalars []*Scalar, points []*Point, scratch *MSMScratch) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called MultiScalarMultWithScratch with different size inputs")
+	}
+	checkInitialized(points...)
+	return v.multiScalarMult(&msmTerms{scalars: scalars, points: points}, scratch)
+}
+
+// VarTimeMultiScalarMultWithScratch sets v = sum(scalars[i] * points[i]), and
+// returns v. It works like VarTimeMultiScalarMult, but uses the buffers in
+// scratch.
+//
+// Execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultWithScratch(scalars []*Scalar, points []*Point, scratch *MSMScratch) *Point {
+	if len(scalars) != len(points) {
+		panic("edwards25519: called VarTimeMultiScalarMultWithScratch with different size inputs")
+	}
+	checkInitialized(points...)
+	return v.varTimeMultiScalarMult(&msmTerms{scalars: scalars, points: points}, nil, scratch)
+}
+
+// msmFuncChunk is the number of terms that MultiScalarMultFunc and
+// VarTimeMultiScalarMultFunc hold in memory at a time.
+const msmFuncChunk = 1024
+
+// MultiScalarMultFunc sets v = sum(s_i * P_i) for i in [0, n), where s_i and
+// P_i are the values returned by term(i), and returns v.
+//
+// The terms are pulled in order, in chunks of a fixed size, and copied before
+// the next call to term, so the caller can reuse the returned values and
+// doesn't need to hold all the inputs in memory at the same time. Each chunk
+// adds about the cost of a ScalarMult, so for small inputs MultiScalarMult is
+// faster.
+//
+// Execution time depends only on n.
+func (v *Point) MultiScalarMultFunc(n int, term func(i int) (*Scalar, *Point)) *Point {
+	return v.multiScalarMultFunc(n, term, false)
+}
+
+// VarTimeMultiScalarMultFunc sets v = sum(s_i * P_i) for i in [0, n), where
+// s_i and P_i are the values returned by term(i), and returns v.
+//
+// It works like MultiScalarMultFunc, but like VarTimeMultiScalarMult its
+// execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultFunc(n int, term func(i int) (*Scalar, *Point)) *Point {
+	return v.multiScalarMultFunc(n, term, true)
+}
+
+func (v *Point) multiScalarMultFunc(n int, term func(i int) (*Scalar, *Point), varTime bool) *Point {
+	if n < 0 {
+		panic("edwards25519: negative number of multi-scalar multiplication terms")
+	}
+	size := n
+	if size > msmFuncChunk {
+		size = msmFuncChunk
+	}
+	scalars, points := make([]Scalar, size), make([]Point, size)
+	var scratch MSMScratch
+
+	sum := NewIdentityPoint()
+	var partial Point
+	for start := 0; start < n; start += size {
+		m := n - start
+		if m > size {
+			m = size
+		}
+		for i := 0; i < m; i++ {
+			s, p := term(start + i)
+			checkInitialized(p)
+			scalars[i].Set(s)
+			points[i].Set(p)
+		}
+		terms := &msmTerms{values: true, scalarValues: scalars[:m], pointValues: points[:m]}
+		if varTime {
+			partial.varTimeMultiScalarMult(terms, nil, &scratch)
+		} else {
+			partial.multiScalarMult(terms, &scratch)
+		}
+		sum.Add(sum, &partial)
+	}
+	return v.Set(sum)
+}
+
+// The following methods return buffers of length n, which are freshly
+// allocated if s is nil, and otherwise reused from previous calls. The
+// contents of the buffers are unspecified.
+
+func (s *MSMScratch) projTables(n int) []projLookupTable {
+	if s == nil {
+		return make([]projLookupTable, n)
+	}
+	if cap(s.projBuf) < n {
+		s.projBuf = make([]projLookupTable, n)
+	}
+	return s.projBuf[:n]
+}
+
+func (s *MSMScratch) radix16Digits(n int) [][64]int8 {
+	if s == nil {
+		return make([][64]int8, n)
+	}
+	if cap(s.radix16Buf) < n {
+		s.radix16Buf = make([][64]int8, n)
+	}
+	return s.radix16Buf[:n]
+}
+
+func (s *MSMScratch) nafTables(n int) []nafLookupTable5 {
+	if s == nil {
+		return make([]nafLookupTable5, n)
+	}
+	if cap(s.nafBuf) < n {
+		s.nafBuf = make([]nafLookupTable5, n)
+	}
+	return s.nafBuf[:n]
+}
+
+func (s *MSMScratch) nafDigits(n int) [][256]int8 {
+	if s == nil {
+		return make([][256]int8, n)
+	}
+	if cap(s.nafDigitsBuf) < n {
+		s.nafDigitsBuf = make([][256]int8, n)
+	}
+	return s.nafDigitsBuf[:n]
+}
+
+func (s *MSMScratch) cachedPoints(n int) []projCached {
+	if s == nil {
+		return make([]projCached, n)
+	}
+	if cap(s.cachedBuf) < n {
+		s.cachedBuf = make([]projCached, n)
+	}
+	return s.cachedBuf[:n]
+}
+
+func (s *MSMScratch) signedDigits(n int) []int32 {
+	if s == nil {
+		return make([]int32, n)
+	}
+	if cap(s.signedBuf) < n {
+		s.signedBuf = make([]int32, n)
+	}
+	return s.signedBuf[:n]
+}
+
+func (s *MSMScratch) buckets(n int) []Point {
+	if s == nil {
+		return make([]Point, n)
+	}
+	if cap(s.bucketBuf) < n {
+		s.bucketBuf = make([]Point, n)
+	}
+	return s.bucketBuf[:n]
+}
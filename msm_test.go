@@ -0,0 +1,103 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestMSM(t *testing.T) {
+	m := NewMSM()
+	f := func(x, y, z Scalar) bool {
+		m.Reset()
+		A := new(Point).ScalarBaseMult(&z)
+
+		// The inputs are copied, so they can be modified after Add.
+		tmp := new(Scalar).Set(&x)
+		m.Add(tmp, A)
+		tmp.Set(&y)
+		m.Add(tmp, dalekScalarBasepoint)
+		m.AddBase(&z)
+		m.AddBase(&x)
+		if m.Len() != 2 {
+			return false
+		}
+
+		var p, q, check Point
+		m.Result(&p)
+		m.VarTimeResult(&q)
+		check.MultiScalarMult([]*Scalar{&x, &y, &z, &x}, []*Point{A, dalekScalarBasepoint, B, B})
+		checkOnCurve(t, &p, &q)
+		return p.Equal(&check) == 1 && q.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var zero MSM
+	var p Point
+	if zero.Result(&p).Equal(I) != 1 || zero.VarTimeResult(&p).Equal(I) != 1 {
+		t.Error("empty MSM did not return the identity")
+	}
+	zero.Add(dalekScalar, B)
+	if zero.Result(&p).Equal(dalekScalarBasepoint) != 1 ||
+		zero.VarTimeResult(&p).Equal(dalekScalarBasepoint) != 1 {
+		t.Error("zero value MSM did not return x * B")
+	}
+}
+
+func TestMultiScalarMultWithScratch(t *testing.T) {
+	var scratch MSMScratch
+	// Grow and shrink the buffers, covering the stack, Straus, and Pippenger
+	// code paths.
+	sizes := []int{2, 10, 3, pippengerThreshold + 1, 20}
+	if testing.Short() {
+		sizes = []int{2, 10, 3, 20}
+	}
+	for _, n := range sizes {
+		scalars, points := msmTestInputs(n)
+		var p, q, check, varCheck Point
+		p.MultiScalarMultWithScratch(scalars, points, &scratch)
+		q.VarTimeMultiScalarMultWithScratch(scalars, points, &scratch)
+		check.MultiScalarMult(scalars, points)
+		varCheck.VarTimeMultiScalarMult(scalars, points)
+		checkOnCurve(t, &p, &q)
+		if p.Equal(&check) != 1 {
+			t.Errorf("n = %d: MultiScalarMultWithScratch does not match MultiScalarMult", n)
+		}
+		if q.Equal(&varCheck) != 1 {
+			t.Errorf("n = %d: VarTimeMultiScalarMultWithScratch does not match VarTimeMultiScalarMult", n)
+		}
+	}
+}
+
+func TestMultiScalarMultFunc(t *testing.T) {
+	sizes := []int{0, 3, msmFuncChunk + 5}
+	if testing.Short() {
+		sizes = []int{0, 3}
+	}
+	for _, n := range sizes {
+		scalars, points := msmTestInputs(n)
+		// The returned values are overwritten by the next call.
+		var s Scalar
+		var q Point
+		term := func(i int) (*Scalar, *Point) {
+			return s.Set(scalars[i]), q.Set(points[i])
+		}
+
+		var p, vp, check Point
+		p.MultiScalarMultFunc(n, term)
+		vp.VarTimeMultiScalarMultFunc(n, term)
+		check.VarTimeMultiScalarMult(scalars, points)
+		checkOnCurve(t, &p, &vp)
+		if p.Equal(&check) != 1 {
+			t.Errorf("n = %d: MultiScalarMultFunc does not match", n)
+		}
+		if vp.Equal(&check) != 1 {
+			t.Errorf("n = %d: VarTimeMultiScalarMultFunc does not match", n)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+// This file implements Pippenger's bucket method for variable-time
+// multi-scalar multiplication, as described in Section 4 of "Faster batch
+// forgery identification" by Bernstein, Doumen, Lange, and Oosterwijk.
+//
+// Each scalar is split into signed digits of c bits. For each window, from the
+// most significant, every point is added to the bucket of its digit, and the
+// buckets are combined as sum(k * bucket[k]) with two running sums. The
+// windows are then joined with c doublings each, like in Straus' method.
+//
+// With n points, each window costs about n + 2^c additions, independently of
+// the digits, while Straus' method costs about n * 256 / (w + 1) additions
+// with NAF width w. For large n, Pippenger's method is asymptotically faster
+// by a factor of log(n).
+
+// pippengerMaxWindow is the largest supported window size, which keeps the
+// buckets of a single window under 6MB.
+const pippengerMaxWindow = 16
+
+// pippengerWindow returns the window size c that minimizes the estimated cost
+// of a multi-scalar multiplication of n points, ceil(256 / c) * (n + 2^c)
+// point additions. The cost is computed as a uint64 so it doesn't overflow on
+// 32-bit platforms.
+func pippengerWindow(n int) uint {
+	best, bestCost := uint(0), uint64(0)
+	for c := uint(2); c <= pippengerMaxWindow; c++ {
+		cost := uint64((256+c-1)/c) * (uint64(n) + 1<<c)
+		if best == 0 || cost < bestCost {
+			best, bestCost = c, cost
+		}
+	}
+	return best
+}
+
+// varTimePippenger sets v = sum(terms) + b * B using windows of c bits, and
+// returns v. If b is nil, the basepoint term is omitted. The inputs must have
+// been checked by the caller.
+func (v *Point) varTimePippenger(terms *msmTerms, b *Scalar, c uint, scratch *MSMScratch) *Point {
+	windows := (256 + int(c) - 1) / int(c)
+
+	// Precompute the cached form of each point and the digits of each scalar.
+	n := terms.len()
+	if b != nil {
+		n++
+	}
+	cached := scratch.cachedPoints(n)
+	digits := scratch.signedDigits(n * windows)
+	for i := 0; i < terms.len(); i++ {
+		cached[i].FromP3(terms.point(i))
+		terms.scalar(i).signedDigits(digits[i*windows:(i+1)*windows], c)
+	}
+	if b != nil {
+		cached[n-1].FromP3(generator)
+		b.signedDigits(digits[(n-1)*windows:], c)
+	}
+
+	buckets := scratch.buckets(1 << (c - 1))
+	var sum, windowSum Point
+	tmpCached := &projCached{}
+	tmp1 := &projP1xP1{}
+	tmp2 := &projP2{}
+
+	// Find the first window with a nonzero digit, to skip the leading ones
+	// when all the scalars are short.
+	top := -1
+	for i := 0; i < n; i++ {
+		for j := windows - 1; j > top; j-- {
+			if digits[i*windows+j] != 0 {
+				top = j
+				break
+			}
+		}
+	}
+
+	v.Set(NewIdentityPoint())
+	for j := top; j >= 0; j-- {
+		// Multiply the accumulator by 2^c, unless it's still the identity.
+		if j != top {
+			tmp2.FromP3(v)
+			for k := uint(0); k < c; k++ {
+				tmp1.Double(tmp2)
+				tmp2.FromP1xP1(tmp1)
+			}
+			v.fromP2(tmp2)
+		}
+
+		// Sort the points into buckets by their digit in this window.
+		for k := range buckets {
+			buckets[k].Set(identity)
+		}
+		for i := range cached {
+			d := digits[i*windows+j]
+			if d > 0 {
+				tmp1.Add(&buckets[d-1], &cached[i])
+				buckets[d-1].fromP1xP1(tmp1)
+			} else if d < 0 {
+				tmp1.Sub(&buckets[-d-1], &cached[i])
+				buckets[-d-1].fromP1xP1(tmp1)
+			}
+		}
+
+		// Compute sum(k * buckets[k-1]) as the sum of the running sums
+		// buckets[k-1] + ... + buckets[len-1], from the top.
+		sum.Set(identity)
+		windowSum.Set(identity)
+		for k := len(buckets) - 1; k >= 0; k-- {
+			tmpCached.FromP3(&buckets[k])
+			tmp1.Add(&sum, tmpCached)
+			sum.fromP1xP1(tmp1)
+			tmpCached.FromP3(&sum)
+			tmp1.Add(&windowSum, tmpCached)
+			windowSum.fromP1xP1(tmp1)
+		}
+
+		tmpCached.FromP3(&windowSum)
+		tmp1.Add(v, tmpCached)
+		v.fromP1xP1(tmp1)
+	}
+	return v
+}
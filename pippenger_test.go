@@ -0,0 +1,138 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"fmt"
+	"testing"
+)
+
+// msmTestInputs returns n pseudo-random scalars and points, derived from
+// powers of dalekScalar.
+func msmTestInputs(n int) ([]*Scalar, []*Point) {
+	scalars, points := make([]*Scalar, n), make([]*Point, n)
+	x := new(Scalar).Set(dalekScalar)
+	p := new(Point).Set(dalekScalarBasepoint)
+	for i := range scalars {
+		scalars[i] = new(Scalar).Set(x)
+		points[i] = new(Point).Set(p)
+		x.Multiply(x, dalekScalar)
+		p.Add(p, dalekScalarBasepoint)
+	}
+	return scalars, points
+}
+
+func TestVarTimePippenger(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 17} {
+		for _, c := range []uint{2, 5, 8, 11} {
+			scalars, points := msmTestInputs(n)
+			// Exercise the edge cases of the digits.
+			if n > 1 {
+				scalars[0] = scMinusOne
+				scalars[1] = NewScalar()
+			}
+
+			terms := &msmTerms{scalars: scalars, points: points}
+			var p, check Point
+			p.varTimePippenger(terms, nil, c, nil)
+			check.varTimeStraus(terms, nil, nil)
+			checkOnCurve(t, &p)
+			if p.Equal(&check) != 1 {
+				t.Errorf("n = %d, c = %d: result does not match Straus", n, c)
+			}
+			p.varTimePippenger(terms, dalekScalar, c, nil)
+			check.varTimeStraus(terms, dalekScalar, nil)
+			if p.Equal(&check) != 1 {
+				t.Errorf("n = %d, c = %d: result with basepoint does not match Straus", n, c)
+			}
+		}
+	}
+}
+
+func TestVarTimeMultiScalarMultLarge(t *testing.T) {
+	n := pippengerThreshold + 1
+	if testing.Short() {
+		n = pippengerThreshold
+	}
+	scalars, points := msmTestInputs(n)
+
+	var p, check Point
+	p.VarTimeMultiScalarBaseMult(scalars, points, dalekScalar)
+	check.varTimeStraus(&msmTerms{scalars: scalars, points: points}, dalekScalar, nil)
+	if p.Equal(&check) != 1 {
+		t.Error("VarTimeMultiScalarBaseMult does not match Straus")
+	}
+
+	// The inputs must not be modified when appending the basepoint.
+	scalars, points = scalars[:n-1], points[:n-1]
+	p.VarTimeMultiScalarBaseMult(scalars, points, dalekScalar)
+	p.VarTimeMultiScalarMult(scalars[:cap(scalars)], points[:cap(points)])
+	check.varTimeStraus(&msmTerms{scalars: scalars[:cap(scalars)], points: points[:cap(points)]}, nil, nil)
+	if p.Equal(&check) != 1 {
+		t.Error("VarTimeMultiScalarMult does not match Straus")
+	}
+}
+
+func TestVarTimeMultiScalarMultParallel(t *testing.T) {
+	scalars, points := msmTestInputs(50)
+	var check Point
+	check.VarTimeMultiScalarMult(scalars, points)
+
+	for _, workers := range []int{0, 1, 2, 7, 50, 100} {
+		var p Point
+		p.VarTimeMultiScalarMultParallel(scalars, points, workers)
+		checkOnCurve(t, &p)
+		if p.Equal(&check) != 1 {
+			t.Errorf("workers = %d: result does not match VarTimeMultiScalarMult", workers)
+		}
+	}
+
+	var p Point
+	p.VarTimeMultiScalarMultParallel(nil, nil, 4)
+	if p.Equal(I) != 1 {
+		t.Error("empty input did not return the identity")
+	}
+}
+
+func TestPippengerWindow(t *testing.T) {
+	prev := pippengerWindow(1)
+	for _, n := range []int{10, 100, 1000, 10000, 100000, 1000000, 100000000} {
+		c := pippengerWindow(n)
+		if c < prev || c > pippengerMaxWindow {
+			t.Errorf("pippengerWindow(%d) = %d, previous was %d", n, c, prev)
+		}
+		prev = c
+	}
+}
+
+func BenchmarkVarTimeMultiScalarMult(b *testing.B) {
+	for _, n := range []int{8, 64, 256, 1024} {
+		scalars, points := msmTestInputs(n)
+		b.Run(fmt.Sprintf("Straus/%d", n), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				p.varTimeStraus(&msmTerms{scalars: scalars, points: points}, nil, nil)
+			}
+		})
+		b.Run(fmt.Sprintf("Pippenger/%d", n), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				p.varTimePippenger(&msmTerms{scalars: scalars, points: points}, nil, pippengerWindow(n), nil)
+			}
+		})
+	}
+}
+
+func BenchmarkVarTimeMultiScalarMultParallel(b *testing.B) {
+	scalars, points := msmTestInputs(4096)
+	for _, workers := range []int{1, 4} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				p.VarTimeMultiScalarMultParallel(scalars, points, workers)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"crypto/subtle"
+	"errors"
+	"runtime"
+	"sync"
+
+	"filippo.io/edwards25519/field"
+)
+
+// A PrecomputedPoint holds precomputed tables for a fixed point P, such as a
+// protocol-specific generator or a long-lived public key, to compute x * P
+// about as fast as ScalarBaseMult computes x * B.
+//
+// A PrecomputedPoint is a comb of ceil(256 / w) tables of 2^(w-1) affine
+// multiples each, where w is the window size. A scalar multiplication costs
+// ceil(256 / w) additions and no doublings, and each constant-time table
+// lookup scans 2^(w-1) entries. Window sizes of 4 to 6 work well for
+// constant-time use, and larger ones benefit VarTimeScalarMult. The tables
+// take 120 * 2^(w-1) * ceil(256 / w) bytes, for example 60KB for w = 4 and
+// 480KB for w = 8.
+//
+// A PrecomputedPoint is safe for concurrent use. The zero value is NOT valid,
+// and it may be used only as the receiver of UnmarshalBinary.
+type PrecomputedPoint struct {
+	w uint
+	// tables[i * 2^(w-1) + j] is (j + 1) * 2^(w*i) * P.
+	tables []AffinePoint
+}
+
+// NewPrecomputedPoint returns a new PrecomputedPoint for p, with windows of
+// windowBits bits. windowBits must be between 2 and 8, inclusive, or
+// NewPrecomputedPoint will panic.
+func NewPrecomputedPoint(p *Point, windowBits int) *PrecomputedPoint {
+	return NewPrecomputedPoints([]*Point{p}, windowBits, 1)[0]
+}
+
+// NewPrecomputedPoints returns a new PrecomputedPoint for each of points, with
+// windows of windowBits bits, like NewPrecomputedPoint.
+//
+// The rows of all the tables are split into up to workers contiguous chunks,
+// which are computed concurrently by separate goroutines, each with a single
+// batched field inversion. If workers is zero, runtime.GOMAXPROCS(0) is used.
+// If workers is one, the computation is done by the calling goroutine.
+func NewPrecomputedPoints(points []*Point, windowBits, workers int) []*PrecomputedPoint {
+	checkInitialized(points...)
+	if windowBits < 2 || windowBits > 8 {
+		panic("edwards25519: invalid PrecomputedPoint window size")
+	}
+	w := uint(windowBits)
+	n := 1 << (w - 1)
+	windows := (256 + windowBits - 1) / windowBits
+	rows := len(points) * windows
+
+	tables := make([]AffinePoint, rows*n)
+	out := make([]*PrecomputedPoint, len(points))
+	for k := range out {
+		t := tables[k*windows*n : (k+1)*windows*n : (k+1)*windows*n]
+		out[k] = &PrecomputedPoint{w: w, tables: t}
+	}
+
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > rows {
+		workers = rows
+	}
+	if workers <= 1 {
+		fillPrecomputedRows(tables, points, w, 0, rows)
+		return out
+	}
+
+	chunk := (rows + workers - 1) / workers
+	var wg sync.WaitGroup
+	for start := 0; start < rows; start += chunk {
+		end := start + chunk
+		if end > rows {
+			end = rows
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			fillPrecomputedRows(tables, points, w, start, end)
+		}(start, end)
+	}
+	wg.Wait()
+	return out
+}
+
+// fillPrecomputedRows computes the rows from start to end of the concatenated
+// tables of points, with windows of w bits. Row r holds the 2^(w-1) multiples
+// of 2^(w * i) * points[k], where k = r / ceil(256 / w) and
+// i = r % ceil(256 / w).
+func fillPrecomputedRows(tables []AffinePoint, points []*Point, w uint, start, end int) {
+	n := 1 << (w - 1)
+	windows := (256 + int(w) - 1) / int(w)
+
+	multiples := make([]Point, (end-start)*n)
+	pointers := make([]*Point, len(multiples))
+	base := new(Point)
+	for r := start; r < end; r++ {
+		k, i := r/windows, r%windows
+		row := multiples[(r-start)*n : (r-start+1)*n]
+		if r == start || i == 0 {
+			base.MultByPow2(points[k], int(w)*i)
+		} else {
+			base.Double(&multiples[(r-start)*n-1]) // 2 * 2^(w-1) * base = 2^w * base
+		}
+		row[0].Set(base)
+		for j := 1; j < n; j++ {
+			row[j].Add(&row[j-1], base)
+		}
+	}
+	for i := range multiples {
+		pointers[i] = &multiples[i]
+	}
+
+	SetAffinePoints(tables[start*n:end*n], pointers)
+}
+
+// precomputedPointVersion is the first byte of the PrecomputedPoint encoding.
+const precomputedPointVersion = 1
+
+// AppendBinary appends the encoding of t to b and returns the extended buffer.
+// It implements the encoding.BinaryAppender interface, and never returns an
+// error.
+//
+// The encoding is a version byte, the window size, and the canonical encoding
+// of the three field elements of each table entry, for a total of
+// 2 + 96 * 2^(w-1) * ceil(256 / w) bytes. It's stable across versions of
+// this package.
+func (t *PrecomputedPoint) AppendBinary(b []byte) ([]byte, error) {
+	t.checkInitialized()
+	b = append(b, precomputedPointVersion, byte(t.w))
+	for i := range t.tables {
+		c := &t.tables[i].c
+		b, _ = c.YplusX.AppendBinary(b)
+		b, _ = c.YminusX.AppendBinary(b)
+		b, _ = c.T2d.AppendBinary(b)
+	}
+	return b, nil
+}
+
+// MarshalBinary returns the encoding of t, as described in AppendBinary. It
+// implements the encoding.BinaryMarshaler interface, and never returns an
+// error.
+func (t *PrecomputedPoint) MarshalBinary() ([]byte, error) {
+	t.checkInitialized()
+	return t.AppendBinary(make([]byte, 0, 2+96*len(t.tables)))
+}
+
+// UnmarshalBinary sets t to the PrecomputedPoint encoded in data, as produced
+// by MarshalBinary. It implements the encoding.BinaryUnmarshaler interface.
+//
+// UnmarshalBinary checks that the encoding is well-formed and that every
+// table entry is a point on the curve, but not that the entries are the
+// correct multiples of each other, which would take about as long as
+// NewPrecomputedPoint. The data must come from a trusted source, such as a
+// file generated at deploy time, since a malicious table can produce
+// arbitrary results. If data is invalid, UnmarshalBinary returns an error and
+// t is unchanged.
+func (t *PrecomputedPoint) UnmarshalBinary(data []byte) error {
+	if len(data) < 2 || data[0] != precomputedPointVersion {
+		return errors.New("edwards25519: invalid PrecomputedPoint encoding version")
+	}
+	w := int(data[1])
+	if w < 2 || w > 8 {
+		return errors.New("edwards25519: invalid PrecomputedPoint window size")
+	}
+	n := (1 << (w - 1)) * ((256 + w - 1) / w)
+	data = data[2:]
+	if len(data) != 96*n {
+		return errors.New("edwards25519: invalid PrecomputedPoint encoding length")
+	}
+
+	tables := make([]AffinePoint, n)
+	for i := range tables {
+		c := &tables[i].c
+		entry := data[96*i : 96*(i+1)]
+		if !setCanonicalFieldElement(&c.YplusX, entry[:32]) ||
+			!setCanonicalFieldElement(&c.YminusX, entry[32:64]) ||
+			!setCanonicalFieldElement(&c.T2d, entry[64:]) {
+			return errors.New("edwards25519: non-canonical PrecomputedPoint encoding")
+		}
+		if !isAffineCachedOnCurve(c) {
+			return errors.New("edwards25519: invalid PrecomputedPoint table entry")
+		}
+	}
+
+	t.w = uint(w)
+	t.tables = tables
+	return nil
+}
+
+// setCanonicalFieldElement sets v to the field element encoded in b, and
+// reports whether b was a canonical encoding, in variable time.
+func setCanonicalFieldElement(v *field.Element, b []byte) bool {
+	// The encoding is canonical if the top bit is unset and the value is
+	// less than p = 2^255 - 19, whose encoding is ed ff ... ff 7f.
+	if b[31] > 0x7f {
+		return false
+	}
+	if b[31] == 0x7f && b[0] >= 0xed {
+		allOnes := true
+		for _, x := range b[1:31] {
+			allOnes = allOnes && x == 0xff
+		}
+		if allOnes {
+			return false
+		}
+	}
+	_, err := v.SetBytes(b)
+	return err == nil
+}
+
+// isAffineCachedOnCurve reports whether c is (y+x, y-x, 2dxy) for a point
+// (x, y) on the curve, in variable time.
+func isAffineCachedOnCurve(c *affineCached) bool {
+	// Like SetAffinePoint, x = ((y+x) - (y-x)) / 2, y = ((y+x) + (y-x)) / 2
+	var x, y, xx, yy, lhs, rhs field.Element
+	x.Subtract(&c.YplusX, &c.YminusX)
+	x.Multiply(&x, feHalf)
+	y.Add(&c.YplusX, &c.YminusX)
+	y.Multiply(&y, feHalf)
+
+	// 2dxy = T2d
+	lhs.Multiply(&x, &y)
+	lhs.Multiply(&lhs, d2)
+	if lhs.EqualVarTime(&c.T2d) != 1 {
+		return false
+	}
+
+	// -x² + y² = 1 + dx²y²
+	xx.Square(&x)
+	yy.Square(&y)
+	lhs.Subtract(&yy, &xx)
+	rhs.Multiply(&xx, &yy)
+	rhs.Multiply(&rhs, d)
+	rhs.Add(&rhs, feOne)
+	return lhs.EqualVarTime(&rhs) == 1
+}
+
+// WindowBits returns the window size t was built with.
+func (t *PrecomputedPoint) WindowBits() int {
+	t.checkInitialized()
+	return int(t.w)
+}
+
+// checkInitialized panics if t is the zero value, which has no tables and
+// can only be used as the receiver of UnmarshalBinary.
+func (t *PrecomputedPoint) checkInitialized() {
+	if t.w == 0 {
+		panic("edwards25519: use of uninitialized PrecomputedPoint")
+	}
+}
+
+// ScalarMult sets v = x * P, where P is the point t was built from, and
+// returns v.
+//
+// The scalar multiplication is done in constant time.
+func (t *PrecomputedPoint) ScalarMult(v *Point, x *Scalar) *Point {
+	v.Set(NewIdentityPoint())
+	t.addScalarMult(v, x)
+	return v
+}
+
+// addScalarMult sets v = v + x * P in constant time.
+func (t *PrecomputedPoint) addScalarMult(v *Point, x *Scalar) {
+	t.checkInitialized()
+	var digitsBuf [128]int32
+	n := 1 << (t.w - 1)
+	windows := len(t.tables) / n
+	digits := digitsBuf[:windows]
+	x.signedDigits(digits, t.w)
+
+	multiple := &affineCached{}
+	tmp1 := &projP1xP1{}
+	for i, d := range digits {
+		// Compute |d| and set multiple = |d| * 2^(w*i) * P in constant time.
+		mask := d >> 31
+		abs := (d ^ mask) - mask
+		multiple.Zero()
+		row := t.tables[i*n : (i+1)*n]
+		for j := range row {
+			cond := subtle.ConstantTimeEq(abs, int32(j+1))
+			multiple.Select(&row[j].c, multiple, cond)
+		}
+		multiple.CondNeg(int(mask & 1))
+
+		tmp1.AddAffine(v, multiple)
+		v.fromP1xP1(tmp1)
+	}
+}
+
+// VarTimeScalarMult sets v = x * P, where P is the point t was built from,
+// and returns v.
+//
+// Execution time depends on the inputs.
+func (t *PrecomputedPoint) VarTimeScalarMult(v *Point, x *Scalar) *Point {
+	v.Set(NewIdentityPoint())
+	t.varTimeAddScalarMult(v, x)
+	return v
+}
+
+// varTimeAddScalarMult sets v = v + x * P in variable time.
+func (t *PrecomputedPoint) varTimeAddScalarMult(v *Point, x *Scalar) {
+	t.checkInitialized()
+	var digitsBuf [128]int32
+	n := 1 << (t.w - 1)
+	windows := len(t.tables) / n
+	digits := digitsBuf[:windows]
+	x.signedDigits(digits, t.w)
+
+	tmp1 := &projP1xP1{}
+	for i, d := range digits {
+		if d > 0 {
+			tmp1.AddAffine(v, &t.tables[i*n+int(d)-1].c)
+			v.fromP1xP1(tmp1)
+		} else if d < 0 {
+			tmp1.SubAffine(v, &t.tables[i*n+int(-d)-1].c)
+			v.fromP1xP1(tmp1)
+		}
+	}
+}
+
+// MultiScalarMultWithPrecomputed sets v = sum(scalars[i] * points[i]) +
+// sum(precomputedScalars[j] * P[j]), where P[j] is the point precomputed[j]
+// was built from, and returns v.
+//
+// Static generators of an equation can be precomputed once with
+// NewPrecomputedPoint, while fresh points use small per-call tables like in
+// MultiScalarMult. The lengths of scalars and points, and of
+// precomputedScalars and precomputed, must match.
+//
+// Execution time depends only on the lengths of the slices and on the window
+// sizes of the precomputed points.
+func (v *Point) MultiScalarMultWithPrecomputed(scalars []*Scalar, points []*Point, precomputedScalars []*Scalar, precomputed []*PrecomputedPoint) *Point {
+	if len(precomputedScalars) != len(precomputed) {
+		panic("edwards25519: called MultiScalarMultWithPrecomputed with different size inputs")
+	}
+	// The comb of a PrecomputedPoint needs no doublings, so there's nothing
+	// to share with the dynamic points, whose tables are built first.
+	v.MultiScalarMult(scalars, points)
+	for i, t := range precomputed {
+		t.addScalarMult(v, precomputedScalars[i])
+	}
+	return v
+}
+
+// VarTimeMultiScalarMultWithPrecomputed sets v = sum(scalars[i] * points[i]) +
+// sum(precomputedScalars[j] * P[j]), where P[j] is the point precomputed[j]
+// was built from, and returns v.
+//
+// It works like MultiScalarMultWithPrecomputed, but like
+// VarTimeMultiScalarMult its execution time depends on the inputs.
+func (v *Point) VarTimeMultiScalarMultWithPrecomputed(scalars []*Scalar, points []*Point, precomputedScalars []*Scalar, precomputed []*PrecomputedPoint) *Point {
+	if len(precomputedScalars) != len(precomputed) {
+		panic("edwards25519: called VarTimeMultiScalarMultWithPrecomputed with different size inputs")
+	}
+	v.VarTimeMultiScalarMult(scalars, points)
+	for i, t := range precomputed {
+		t.varTimeAddScalarMult(v, precomputedScalars[i])
+	}
+	return v
+}
@@ -0,0 +1,220 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"testing/quick"
+)
+
+func TestPrecomputedPoint(t *testing.T) {
+	for w := 2; w <= 8; w++ {
+		table := NewPrecomputedPoint(dalekScalarBasepoint, w)
+		if table.WindowBits() != w {
+			t.Errorf("WindowBits() = %d, want %d", table.WindowBits(), w)
+		}
+		f := func(x Scalar) bool {
+			var p, q, check Point
+			table.ScalarMult(&p, &x)
+			table.VarTimeScalarMult(&q, &x)
+			check.ScalarMult(&x, dalekScalarBasepoint)
+			checkOnCurve(t, &p, &q)
+			return p.Equal(&check) == 1 && q.Equal(&check) == 1
+		}
+		if err := quick.Check(f, quickCheckConfig(16)); err != nil {
+			t.Errorf("w = %d: %v", w, err)
+		}
+		for _, x := range []*Scalar{NewScalar(), scOne, scMinusOne} {
+			if !f(*x) {
+				t.Errorf("w = %d: failed for %x", w, x.Bytes())
+			}
+		}
+	}
+
+	for _, w := range []int{1, 9} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("w = %d: did not panic", w)
+				}
+			}()
+			NewPrecomputedPoint(B, w)
+		}()
+	}
+
+	var zero PrecomputedPoint
+	for name, f := range map[string]func(){
+		"ScalarMult":        func() { zero.ScalarMult(new(Point), dalekScalar) },
+		"VarTimeScalarMult": func() { zero.VarTimeScalarMult(new(Point), dalekScalar) },
+		"WindowBits":        func() { zero.WindowBits() },
+		"MarshalBinary":     func() { zero.MarshalBinary() },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s on the zero value did not panic", name)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestMultiScalarMultWithPrecomputed(t *testing.T) {
+	H := NewPrecomputedPoint(dalekScalarBasepoint, 5)
+	G := NewPrecomputedPoint(B, 4)
+	f := func(x, y, z Scalar) bool {
+		A := new(Point).ScalarBaseMult(&z)
+		var p, q, check Point
+		p.MultiScalarMultWithPrecomputed([]*Scalar{&x}, []*Point{A},
+			[]*Scalar{&y, &z}, []*PrecomputedPoint{H, G})
+		// The receiver may alias the dynamic points.
+		q.Set(A)
+		q.VarTimeMultiScalarMultWithPrecomputed([]*Scalar{&x}, []*Point{&q},
+			[]*Scalar{&y, &z}, []*PrecomputedPoint{H, G})
+		check.MultiScalarMult([]*Scalar{&x, &y, &z}, []*Point{A, dalekScalarBasepoint, B})
+		checkOnCurve(t, &p, &q)
+		return p.Equal(&check) == 1 && q.Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	var p Point
+	p.MultiScalarMultWithPrecomputed(nil, nil, nil, nil)
+	if p.Equal(I) != 1 {
+		t.Error("empty inputs did not return the identity")
+	}
+	p.VarTimeMultiScalarMultWithPrecomputed(nil, nil, []*Scalar{dalekScalar}, []*PrecomputedPoint{G})
+	if p.Equal(dalekScalarBasepoint) != 1 {
+		t.Error("no dynamic points did not return x * B")
+	}
+}
+
+func TestPrecomputedPointMarshal(t *testing.T) {
+	table := NewPrecomputedPoint(dalekScalarBasepoint, 3)
+	enc, err := table.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(enc) != 2+96*4*86 {
+		t.Errorf("encoding is %d bytes", len(enc))
+	}
+	prefix := []byte("prefix")
+	if out, _ := table.AppendBinary(prefix); string(out) != string(prefix)+string(enc) {
+		t.Error("AppendBinary does not match MarshalBinary")
+	}
+
+	loaded := new(PrecomputedPoint)
+	if err := loaded.UnmarshalBinary(enc); err != nil {
+		t.Fatal(err)
+	}
+	var p, check Point
+	loaded.ScalarMult(&p, dalekScalar)
+	check.ScalarMult(dalekScalar, dalekScalarBasepoint)
+	if p.Equal(&check) != 1 {
+		t.Error("loaded table computes the wrong result")
+	}
+
+	corrupt := func(f func(b []byte) []byte) []byte {
+		return f(append([]byte{}, enc...))
+	}
+	for name, data := range map[string][]byte{
+		"empty":    {},
+		"version":  corrupt(func(b []byte) []byte { b[0] = 2; return b }),
+		"window":   corrupt(func(b []byte) []byte { b[1] = 9; return b }),
+		"length":   corrupt(func(b []byte) []byte { return b[:len(b)-1] }),
+		"window 4": corrupt(func(b []byte) []byte { b[1] = 4; return b }),
+		"canonical": corrupt(func(b []byte) []byte {
+			copy(b[2:], decodeHex("edffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"))
+			return b
+		}),
+		"off curve": corrupt(func(b []byte) []byte { b[100]++; return b }),
+	} {
+		before := *loaded
+		if err := loaded.UnmarshalBinary(data); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+		if loaded.w != before.w || &loaded.tables[0] != &before.tables[0] {
+			t.Errorf("%s: receiver was modified", name)
+		}
+	}
+}
+
+func TestNewPrecomputedPoints(t *testing.T) {
+	points := []*Point{B, dalekScalarBasepoint, new(Point).Double(B)}
+	for _, w := range []int{2, 5, 8} {
+		want := make([][]byte, len(points))
+		for k, p := range points {
+			want[k], _ = NewPrecomputedPoint(p, w).MarshalBinary()
+		}
+		// Include worker counts that split tables in the middle of a point.
+		for _, workers := range []int{0, 1, 2, 7, 1000} {
+			got := NewPrecomputedPoints(points, w, workers)
+			if len(got) != len(points) {
+				t.Fatalf("w = %d, workers = %d: got %d tables", w, workers, len(got))
+			}
+			for k := range got {
+				enc, _ := got[k].MarshalBinary()
+				if !bytes.Equal(enc, want[k]) {
+					t.Errorf("w = %d, workers = %d: table %d does not match NewPrecomputedPoint", w, workers, k)
+				}
+			}
+		}
+	}
+
+	if got := NewPrecomputedPoints(nil, 4, 0); len(got) != 0 {
+		t.Errorf("got %d tables for no points", len(got))
+	}
+}
+
+func BenchmarkPrecomputedPoint(b *testing.B) {
+	for _, w := range []int{4, 6, 8} {
+		table := NewPrecomputedPoint(dalekScalarBasepoint, w)
+		b.Run(fmt.Sprintf("w=%d/ScalarMult", w), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				table.ScalarMult(&p, dalekScalar)
+			}
+		})
+		b.Run(fmt.Sprintf("w=%d/VarTimeScalarMult", w), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				table.VarTimeScalarMult(&p, dalekScalar)
+			}
+		})
+	}
+	b.Run("New/w=4", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewPrecomputedPoint(dalekScalarBasepoint, 4)
+		}
+	})
+	_, points := msmTestInputs(64)
+	for _, workers := range []int{1, 0} {
+		b.Run(fmt.Sprintf("NewPoints/n=64/w=4/workers=%d", workers), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				NewPrecomputedPoints(points, 4, workers)
+			}
+		})
+	}
+}
+
+func BenchmarkPrecomputedPointUnmarshal(b *testing.B) {
+	enc, _ := NewPrecomputedPoint(dalekScalarBasepoint, 8).MarshalBinary()
+	b.Run("New", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewPrecomputedPoint(dalekScalarBasepoint, 8)
+		}
+	})
+	b.Run("UnmarshalBinary", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := new(PrecomputedPoint).UnmarshalBinary(enc); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
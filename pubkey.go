@@ -0,0 +1,112 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"sync"
+)
+
+// A PublicKey is a decoded Ed25519 public key.
+//
+// A PublicKey caches the results of decoding and validating the key, and lazily
+// builds the precomputed table used by VarTimeDoubleScalarBaseMult, so it's
+// meant to be created once and then reused across operations with the same key.
+// It is safe for concurrent use.
+type PublicKey struct {
+	point      Point
+	encoding   [32]byte
+	smallOrder bool
+
+	tableOnce sync.Once
+	table     nafLookupTable5
+
+	torsionOnce sync.Once
+	torsionFree bool
+}
+
+// NewPublicKey decodes an Ed25519 public key. If pub is not the encoding of a
+// valid point on the curve, NewPublicKey returns nil and an error.
+//
+// Like Point.SetBytes, NewPublicKey accepts non-canonical encodings and points
+// of small or mixed order. Use IsCanonical, IsSmallOrder, and IsTorsionFree to
+// enforce stricter validation rules.
+func NewPublicKey(pub ed25519.PublicKey) (*PublicKey, error) {
+	if len(pub) != ed25519.PublicKeySize {
+		return nil, errors.New("edwards25519: invalid public key length")
+	}
+	k := &PublicKey{}
+	if _, err := k.point.SetBytes(pub); err != nil {
+		return nil, err
+	}
+	copy(k.encoding[:], pub)
+	var p8 Point
+	k.smallOrder = p8.MultByCofactor(&k.point).Equal(identity) == 1
+	return k, nil
+}
+
+// Bytes returns the encoding the PublicKey was decoded from. It can be used to
+// identify the key, for example as a map key.
+func (k *PublicKey) Bytes() []byte {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var buf [32]byte
+	return k.bytes(&buf)
+}
+
+func (k *PublicKey) bytes(buf *[32]byte) []byte {
+	copy(buf[:], k.encoding[:])
+	return buf[:]
+}
+
+// Point returns a new Point set to the decoded public key.
+func (k *PublicKey) Point() *Point {
+	return new(Point).Set(&k.point)
+}
+
+// IsCanonical reports whether the PublicKey was decoded from the canonical
+// encoding of its point, according to RFC 8032, Section 5.1.2.
+func (k *PublicKey) IsCanonical() bool {
+	var buf [32]byte
+	return k.encoding == *(*[32]byte)(k.point.bytes(&buf))
+}
+
+// IsSmallOrder reports whether the PublicKey is one of the eight points of
+// small order, including the identity.
+func (k *PublicKey) IsSmallOrder() bool {
+	return k.smallOrder
+}
+
+// IsTorsionFree reports whether the PublicKey is in the prime order subgroup,
+// that is, whether it has no small order component.
+//
+// The result is computed the first time IsTorsionFree is called, in variable
+// time, and then cached.
+func (k *PublicKey) IsTorsionFree() bool {
+	k.torsionOnce.Do(func() {
+		// l * P = (l - 1) * P + P is the identity iff P is torsion-free.
+		var p Point
+		p.varTimeDoubleScalarBaseMult(scalarMinusOne, k.nafTable(), NewScalar())
+		k.torsionFree = p.Add(&p, &k.point).Equal(identity) == 1
+	})
+	return k.torsionFree
+}
+
+func (k *PublicKey) nafTable() *nafLookupTable5 {
+	k.tableOnce.Do(func() {
+		k.table.FromP3(&k.point)
+	})
+	return &k.table
+}
+
+// VarTimeDoubleScalarBaseMult sets v = a * A + b * B, where A is the PublicKey
+// and B is the canonical generator, and returns v.
+//
+// It works like Point.VarTimeDoubleScalarBaseMult, but reuses the lookup table
+// for A across calls. Execution time depends on the inputs.
+func (k *PublicKey) VarTimeDoubleScalarBaseMult(v *Point, a, b *Scalar) *Point {
+	return v.varTimeDoubleScalarBaseMult(a, k.nafTable(), b)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+	"testing/quick"
+)
+
+func TestPublicKey(t *testing.T) {
+	pub, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(k.Bytes(), pub) {
+		t.Errorf("Bytes() = %x, want %x", k.Bytes(), pub)
+	}
+	if !bytes.Equal(k.Point().Bytes(), pub) {
+		t.Errorf("Point().Bytes() = %x, want %x", k.Point().Bytes(), pub)
+	}
+	if !k.IsCanonical() || k.IsSmallOrder() || !k.IsTorsionFree() {
+		t.Errorf("unexpected validation results for a regular public key")
+	}
+
+	f := func(a, b Scalar) bool {
+		var got, want Point
+		k.VarTimeDoubleScalarBaseMult(&got, &a, &b)
+		want.VarTimeDoubleScalarBaseMult(&a, k.Point(), &b)
+		checkOnCurve(t, &got)
+		return got.Equal(&want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPublicKeyValidation(t *testing.T) {
+	lowOrder := decodeHex("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85")
+	lowOrderPoint, err := new(Point).SetBytes(lowOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mixedOrder := new(Point).Add(dalekScalarBasepoint, lowOrderPoint).Bytes()
+	tests := []struct {
+		name                               string
+		encoding                           []byte
+		canonical, smallOrder, torsionFree bool
+	}{
+		{"basepoint", B.Bytes(), true, false, true},
+		{"identity", I.Bytes(), true, true, true},
+		{"identity,sign-", decodeHex("0100000000000000000000000000000000000000000000000000000000000080"), false, true, true},
+		{"low order", lowOrder, true, true, false},
+		{"mixed order", mixedOrder, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			k, err := NewPublicKey(tt.encoding)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := k.IsCanonical(); got != tt.canonical {
+				t.Errorf("IsCanonical() = %v, want %v", got, tt.canonical)
+			}
+			if got := k.IsSmallOrder(); got != tt.smallOrder {
+				t.Errorf("IsSmallOrder() = %v, want %v", got, tt.smallOrder)
+			}
+			if got := k.IsTorsionFree(); got != tt.torsionFree {
+				t.Errorf("IsTorsionFree() = %v, want %v", got, tt.torsionFree)
+			}
+		})
+	}
+
+	if _, err := NewPublicKey(make([]byte, 31)); err == nil {
+		t.Error("expected error for short public key")
+	}
+	invalid := decodeHex("efffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	if _, err := NewPublicKey(invalid); err == nil {
+		t.Error("expected error for invalid point")
+	}
+}
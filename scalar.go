@@ -7,6 +7,8 @@ package edwards25519
 import (
 	"encoding/binary"
 	"errors"
+	"io"
+	"math/big"
 )
 
 // A Scalar is an integer modulo
@@ -100,6 +102,17 @@ func (s *Scalar) Set(x *Scalar) *Scalar {
 	return s
 }
 
+// Reset sets s to zero, overwriting its previous value. It is meant for
+// recycling Scalars, for example through a sync.Pool, without retaining
+// their previous values.
+//
+// Reset can also be used to scrub secret scalars once they are no longer
+// needed. Note that it can't reach copies made by the caller or by the Go
+// runtime, for example when growing a stack.
+func (s *Scalar) Reset() {
+	*s = Scalar{}
+}
+
 // SetUniformBytes sets s = x mod l, where x is a 64-byte little-endian integer.
 // If x is not of the right length, SetUniformBytes returns nil and an error,
 // and the receiver is unchanged.
@@ -110,7 +123,29 @@ func (s *Scalar) SetUniformBytes(x []byte) (*Scalar, error) {
 	if len(x) != 64 {
 		return nil, errors.New("edwards25519: invalid SetUniformBytes input length")
 	}
+	return s.SetWideBytes((*[64]byte)(x)), nil
+}
 
+// SetRandom sets s to a uniformly distributed random value, by reading 64
+// bytes from rand and passing them to SetUniformBytes, and returns s. rand
+// should be a cryptographically secure source, such as crypto/rand.Reader.
+//
+// If reading from rand fails, SetRandom returns nil and the error, and the
+// receiver is unchanged.
+func (s *Scalar) SetRandom(rand io.Reader) (*Scalar, error) {
+	var buf [64]byte
+	if _, err := io.ReadFull(rand, buf[:]); err != nil {
+		return nil, err
+	}
+	return s.SetWideBytes(&buf), nil
+}
+
+// SetWideBytes sets s = x mod l, where x is a 64-byte little-endian integer,
+// and returns s.
+//
+// SetWideBytes can be used to reduce the 512-bit product of two 256-bit values,
+// such as when implementing fused operations outside this package.
+func (s *Scalar) SetWideBytes(x *[64]byte) *Scalar {
 	// We have a value x of 512 bits, but our fiatScalarFromBytes function
 	// expects an input lower than l, which is a little over 252 bits.
 	//
@@ -128,7 +163,7 @@ func (s *Scalar) SetUniformBytes(x []byte) (*Scalar, error) {
 	t.setShortBytes(x[42:])
 	s.Add(s, t.Multiply(t, scalarTwo336))
 
-	return s, nil
+	return s
 }
 
 // scalarTwo168 and scalarTwo336 are 2^168 and 2^336 modulo l, encoded as a
@@ -172,6 +207,9 @@ func (s *Scalar) SetCanonicalBytes(x []byte) (*Scalar, error) {
 // scalarMinusOneBytes is l - 1 in little endian.
 var scalarMinusOneBytes = [32]byte{236, 211, 245, 92, 26, 99, 18, 88, 214, 156, 247, 162, 222, 249, 222, 20, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 16}
 
+// scalarMinusOne is l - 1.
+var scalarMinusOne, _ = new(Scalar).SetCanonicalBytes(scalarMinusOneBytes[:])
+
 // isReduced returns whether the given scalar in 32-byte little endian encoded
 // form is reduced modulo l.
 func isReduced(s []byte) bool {
@@ -220,6 +258,90 @@ func (s *Scalar) SetBytesWithClamping(x []byte) (*Scalar, error) {
 	return s.SetUniformBytes(wideBytes[:])
 }
 
+// A ReductionMode selects how Scalar.SetBytes handles its input.
+type ReductionMode int
+
+const (
+	// ReductionCanonical accepts only 32-byte canonical encodings, that is
+	// values less than l, like SetCanonicalBytes. Use it to decode scalars
+	// that were encoded with Bytes, such as signature components.
+	ReductionCanonical ReductionMode = iota
+
+	// ReductionModOrder accepts any 32-byte little-endian integer, and
+	// reduces it modulo l. The result is not uniformly distributed, even if
+	// the input is: use ReductionWide to map random bytes to a scalar.
+	ReductionModOrder
+
+	// ReductionWide accepts any 64-byte little-endian integer, and reduces it
+	// modulo l, like SetUniformBytes. Use it to map the output of a hash or
+	// of a random source to a uniformly distributed scalar.
+	ReductionWide
+)
+
+// SetBytes sets s to the value encoded by x according to mode, and returns s.
+//
+// If x is not of the length required by mode, or if mode is
+// ReductionCanonical and x is not a canonical encoding, SetBytes returns nil
+// and an error, and the receiver is unchanged. SetBytes panics if mode is not
+// a valid ReductionMode.
+func (s *Scalar) SetBytes(x []byte, mode ReductionMode) (*Scalar, error) {
+	switch mode {
+	case ReductionCanonical:
+		return s.SetCanonicalBytes(x)
+	case ReductionModOrder:
+		if len(x) != 32 {
+			return nil, errors.New("edwards25519: invalid SetBytes input length")
+		}
+		var wide [64]byte
+		copy(wide[:], x)
+		return s.SetWideBytes(&wide), nil
+	case ReductionWide:
+		return s.SetUniformBytes(x)
+	default:
+		panic("edwards25519: invalid ReductionMode")
+	}
+}
+
+// SetBytesBE works like SetBytes, but x is a big-endian integer, as used by
+// math/big and by some specifications.
+func (s *Scalar) SetBytesBE(x []byte, mode ReductionMode) (*Scalar, error) {
+	var buf [64]byte
+	if len(x) > len(buf) {
+		return nil, errors.New("edwards25519: invalid SetBytesBE input length")
+	}
+	le := buf[:len(x)]
+	for i := range le {
+		le[i] = x[len(x)-1-i]
+	}
+	return s.SetBytes(le, mode)
+}
+
+// scalarOrder is l as a big.Int.
+var scalarOrder, _ = new(big.Int).SetString("7237005577332262213973186563042994240857116359379907606001950938285454250989", 10)
+
+// SetBigInt sets s to the value of x according to mode, and returns s.
+//
+// With ReductionCanonical, x must be in [0, l), or SetBigInt returns nil and
+// an error, and the receiver is unchanged. With ReductionModOrder or
+// ReductionWide, x can be any integer, including negative ones, and is
+// reduced modulo l. SetBigInt panics if mode is not a valid ReductionMode.
+//
+// Like most math/big operations, SetBigInt is not constant time.
+func (s *Scalar) SetBigInt(x *big.Int, mode ReductionMode) (*Scalar, error) {
+	switch mode {
+	case ReductionCanonical:
+		if x.Sign() < 0 || x.Cmp(scalarOrder) >= 0 {
+			return nil, errors.New("edwards25519: SetBigInt input out of range")
+		}
+	case ReductionModOrder, ReductionWide:
+	default:
+		panic("edwards25519: invalid ReductionMode")
+	}
+	var buf [32]byte
+	new(big.Int).Mod(x, scalarOrder).FillBytes(buf[:])
+	return s.SetBytesBE(buf[:], ReductionCanonical)
+}
+
 // Bytes returns the canonical 32-byte little-endian encoding of s.
 func (s *Scalar) Bytes() []byte {
 	// This function is outlined to make the allocations inline in the caller
@@ -235,6 +357,88 @@ func (s *Scalar) bytes(out *[32]byte) []byte {
 	return out[:]
 }
 
+// BytesBE returns the canonical 32-byte big-endian encoding of s, which is
+// the reverse of Bytes.
+func (s *Scalar) BytesBE() []byte {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var encoded [32]byte
+	return s.bytesBE(&encoded)
+}
+
+func (s *Scalar) bytesBE(out *[32]byte) []byte {
+	s.bytes(out)
+	for i := 0; i < 16; i++ {
+		out[i], out[31-i] = out[31-i], out[i]
+	}
+	return out[:]
+}
+
+// BigInt returns the canonical value of s, in [0, l), as a new big.Int.
+func (s *Scalar) BigInt() *big.Int {
+	var buf [32]byte
+	return new(big.Int).SetBytes(s.bytesBE(&buf))
+}
+
+// WideBytes returns the canonical little-endian encoding of s, zero-padded to
+// 64 bytes. It is the inverse of SetUniformBytes for values less than l.
+func (s *Scalar) WideBytes() []byte {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var encoded [64]byte
+	return s.wideBytes(&encoded)
+}
+
+func (s *Scalar) wideBytes(out *[64]byte) []byte {
+	s.bytes((*[32]byte)(out[:32]))
+	return out[:]
+}
+
+// FillBytes sets buf to the canonical little-endian encoding of s, zero-padded
+// to the length of buf, and returns buf. It panics if buf is shorter than 32
+// bytes. To append the encoding to an existing buffer, use AppendBinary.
+func (s *Scalar) FillBytes(buf []byte) []byte {
+	if len(buf) < 32 {
+		panic("edwards25519: FillBytes called with a buffer shorter than 32 bytes")
+	}
+	s.bytes((*[32]byte)(buf))
+	for i := 32; i < len(buf); i++ {
+		buf[i] = 0
+	}
+	return buf
+}
+
+// AppendBinary appends the canonical 32-byte little-endian encoding of s, as
+// returned by Bytes, to b and returns the extended buffer. It implements the
+// encoding.BinaryAppender interface, and never returns an error.
+func (s *Scalar) AppendBinary(b []byte) ([]byte, error) {
+	var buf [32]byte
+	return append(b, s.bytes(&buf)...), nil
+}
+
+// IsOdd returns 1 if the canonical value of s is odd, and 0 otherwise. It runs
+// in constant time.
+func (s *Scalar) IsOdd() int {
+	return int(s.LowBits(1))
+}
+
+// LowBits returns the n least significant bits of the canonical value of s,
+// in constant time. It panics if n is not between 0 and 64.
+//
+// LowBits is faster than extracting the bits from the encoding returned by
+// Bytes, as it only needs to convert s out of the Montgomery domain.
+func (s *Scalar) LowBits(n int) uint64 {
+	if n < 0 || n > 64 {
+		panic("edwards25519: invalid number of bits passed to LowBits")
+	}
+	var ss fiatScalarNonMontgomeryDomainFieldElement
+	fiatScalarFromMontgomery(&ss, &s.s)
+	if n == 64 {
+		return ss[0]
+	}
+	return ss[0] & (1<<n - 1)
+}
+
 // Equal returns 1 if s and t are equal, and 0 otherwise.
 func (s *Scalar) Equal(t *Scalar) int {
 	var diff fiatScalarMontgomeryDomainFieldElement
@@ -250,6 +454,83 @@ func (s *Scalar) Equal(t *Scalar) int {
 	return int(^nonzero) & 1
 }
 
+// LessThanBytes returns 1 if s is less than bound, and 0 otherwise, where bound
+// is a little-endian integer of any length. s is compared as its canonical
+// value in [0, l).
+//
+// The comparison is done in constant time, which depends only on len(bound).
+func (s *Scalar) LessThanBytes(bound []byte) int {
+	var buf [32]byte
+	b := s.bytes(&buf)
+
+	n := len(bound)
+	if n < len(b) {
+		n = len(b)
+	}
+
+	// Compute the borrow of s - bound, which is set iff s < bound.
+	var borrow uint64
+	for i := 0; i < n; i++ {
+		var sb, bb uint64
+		if i < len(b) {
+			sb = uint64(b[i])
+		}
+		if i < len(bound) {
+			bb = uint64(bound[i])
+		}
+		borrow = (sb - bb - borrow) >> 63
+	}
+	return int(borrow)
+}
+
+// CmpVarTime compares the canonical values of s and t, in [0, l), and returns
+// -1 if s < t, 0 if s == t, and +1 if s > t.
+//
+// Execution time depends on the inputs, so CmpVarTime must only be used with
+// public values, for example to sort public commitments.
+func (s *Scalar) CmpVarTime(t *Scalar) int {
+	var ss, tt fiatScalarNonMontgomeryDomainFieldElement
+	fiatScalarFromMontgomery(&ss, &s.s)
+	fiatScalarFromMontgomery(&tt, &t.s)
+	for i := len(ss) - 1; i >= 0; i-- {
+		switch {
+		case ss[i] < tt[i]:
+			return -1
+		case ss[i] > tt[i]:
+			return +1
+		}
+	}
+	return 0
+}
+
+// scalarHalfOrderBytes is (l + 1) / 2 in little endian, the smallest value not
+// in the lower half of the scalar range.
+var scalarHalfOrderBytes = [32]byte{247, 233, 122, 46, 141, 49, 9, 44, 107, 206, 123, 81, 239, 124, 111, 10, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 8}
+
+// IsLowHalf returns 1 if s is in the lower half of the scalar range, that is
+// if s <= (l - 1) / 2, and 0 otherwise. It runs in constant time.
+//
+// IsLowHalf can be used to enforce the "low S" signature malleability rules
+// adopted by some protocols, which accept only one of S and l - S.
+func (s *Scalar) IsLowHalf() int {
+	return s.LessThanBytes(scalarHalfOrderBytes[:])
+}
+
+// SetLowHalf sets s to whichever of x and -x is in the lower half of the
+// scalar range (see IsLowHalf), and returns s. It runs in constant time.
+//
+// SetLowHalf can be used to produce the canonical "low S" representative
+// required by some protocols.
+func (s *Scalar) SetLowHalf(x *Scalar) *Scalar {
+	var neg Scalar
+	neg.Negate(x)
+	cond := fiatScalarUint1(1 - x.IsLowHalf())
+	for i := range s.s {
+		fiatScalarCmovznzU64(&s.s[i], cond, x.s[i], neg.s[i])
+	}
+	return s
+}
+
 // nonAdjacentForm computes a width-w non-adjacent form for this scalar.
 //
 // w must be between 2 and 8, or nonAdjacentForm will panic.
@@ -341,3 +622,35 @@ func (s *Scalar) signedRadix16() [64]int8 {
 
 	return digits
 }
+
+// signedDigits writes to digits the signed radix 2^c representation of s,
+// such that s = sum(digits[j] * 2^(c*j)), with each digit in the range
+// [-2^(c-1), 2^(c-1)]. digits must have ceil(256 / c) entries, and c must be
+// at most 16. The computation is done in constant time.
+func (s *Scalar) signedDigits(digits []int32, c uint) {
+	var b [32]byte
+	s.bytes(&b)
+	var limbs [4]uint64
+	for i := range limbs {
+		limbs[i] = binary.LittleEndian.Uint64(b[i*8:])
+	}
+
+	window := uint64(1)<<c - 1
+	half := int64(1) << (c - 1)
+	var carry int64
+	for j := range digits {
+		pos := uint(j) * c
+		w, off := pos/64, pos%64
+		bits := limbs[w] >> off
+		if off+c > 64 && w+1 < 4 {
+			bits |= limbs[w+1] << (64 - off)
+		}
+		// d is in [0, 2^c], and it's recentered if it's larger than 2^(c-1).
+		// Since s < 2^253, the top window is always at most 2^(c-1) and
+		// produces no carry.
+		d := int64(bits&window) + carry
+		carry = (d + half - 1) >> c
+		d -= carry << c
+		digits[j] = int32(d)
+	}
+}
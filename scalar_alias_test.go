@@ -78,6 +78,12 @@ func TestScalarAliasing(t *testing.T) {
 		"Invert": func(v, x Scalar) bool {
 			return checkAliasingOneArg((*Scalar).Invert, v, x)
 		},
+		"InvertVarTime": func(v, x Scalar) bool {
+			return checkAliasingOneArg((*Scalar).InvertVarTime, v, x)
+		},
+		"SetLowHalf": func(v, x Scalar) bool {
+			return checkAliasingOneArg((*Scalar).SetLowHalf, v, x)
+		},
 		"Multiply": func(v, x, y Scalar) bool {
 			return checkAliasingTwoArgs((*Scalar).Multiply, v, x, y)
 		},
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import "filippo.io/edwards25519/internal/safegcd"
+
+// This file implements scalar inversion with the safegcd algorithm, which is
+// shared with field inversion in the internal/safegcd package. See there for
+// more details. Only the parts that depend on l are here.
+
+// lSigned62 is l = 2^252 + 27742317777372353535851937790883648493.
+var lSigned62 = safegcd.Signed62{0x1812631a5cf5d3ed, 0x137be77a8bde7359, 1, 0, 16}
+
+// lInv62 is 1 / l mod 2^62.
+const lInv62 = 0x2d4ae25cedab81e5
+
+const mask62 = safegcd.Mask62
+
+// invertSafegcd sets s = 1 / t mod l, and returns s. If t == 0, it returns
+// s = 0.
+func (s *Scalar) invertSafegcd(t *Scalar) *Scalar {
+	var inv safegcd.Inverter
+	inv.Init(t.toSigned62(), &lSigned62)
+	for inv.Next() {
+		scalarUpdateDE(&inv.D, &inv.E, &inv.T)
+	}
+	d := inv.Result()
+	return s.fromSigned62(&d)
+}
+
+// invertSafegcdVarTime works like invertSafegcd, but in variable time.
+func (s *Scalar) invertSafegcdVarTime(t *Scalar) *Scalar {
+	var inv safegcd.Inverter
+	inv.InitVarTime(t.toSigned62(), &lSigned62)
+	for inv.Next() {
+		scalarUpdateDE(&inv.D, &inv.E, &inv.T)
+	}
+	d := inv.Result()
+	return s.fromSigned62(&d)
+}
+
+// scalarUpdateDE sets d, e = t * [d, e] / 2^62 mod l, with d and e in
+// (-2l, l).
+func scalarUpdateDE(d, e *safegcd.Signed62, t *[4]int64) {
+	u, v, q, r := t[0], t[1], t[2], t[3]
+
+	// Add a multiple of l to make the bottom 62 bits of the product zero, so
+	// it can be divided by 2^62. md and me start as [u, q] if d is negative,
+	// plus [v, r] if e is negative, to keep the results in range.
+	sd, se := d[4]>>63, e[4]>>63
+	md := (u & sd) + (v & se)
+	me := (q & sd) + (r & se)
+	var cd, ce safegcd.Int128
+	cd = cd.AddMul(u, d[0]).AddMul(v, e[0])
+	ce = ce.AddMul(q, d[0]).AddMul(r, e[0])
+	md -= int64((lInv62*cd.Lo + uint64(md)) & mask62)
+	me -= int64((lInv62*ce.Lo + uint64(me)) & mask62)
+	cd = cd.AddMul(lSigned62[0], md).Shr62()
+	ce = ce.AddMul(lSigned62[0], me).Shr62()
+
+	// Unlike p, l has nonzero middle limbs.
+	for i := 1; i < 5; i++ {
+		cd = cd.AddMul(u, d[i]).AddMul(v, e[i]).AddMul(lSigned62[i], md)
+		ce = ce.AddMul(q, d[i]).AddMul(r, e[i]).AddMul(lSigned62[i], me)
+		d[i-1] = int64(cd.Lo & mask62)
+		e[i-1] = int64(ce.Lo & mask62)
+		cd, ce = cd.Shr62(), ce.Shr62()
+	}
+	d[4] = int64(cd.Lo)
+	e[4] = int64(ce.Lo)
+}
+
+// toSigned62 returns s as a safegcd.Signed62.
+func (s *Scalar) toSigned62() safegcd.Signed62 {
+	var w fiatScalarNonMontgomeryDomainFieldElement
+	fiatScalarFromMontgomery(&w, &s.s)
+	return safegcd.Signed62{
+		int64(w[0] & mask62),
+		int64((w[0]>>62 | w[1]<<2) & mask62),
+		int64((w[1]>>60 | w[2]<<4) & mask62),
+		int64((w[2]>>58 | w[3]<<6) & mask62),
+		int64(w[3] >> 56),
+	}
+}
+
+// fromSigned62 sets s to x, which must be in [0, l), and returns s.
+func (s *Scalar) fromSigned62(x *safegcd.Signed62) *Scalar {
+	s0, s1, s2, s3, s4 := uint64(x[0]), uint64(x[1]), uint64(x[2]), uint64(x[3]), uint64(x[4])
+	w := fiatScalarNonMontgomeryDomainFieldElement{
+		s0 | s1<<62,
+		s1>>2 | s2<<60,
+		s2>>4 | s3<<58,
+		s3>>6 | s4<<56,
+	}
+	fiatScalarToMontgomery(&s.s, &w)
+	return s
+}
@@ -6,6 +6,8 @@ package edwards25519
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/binary"
 	"encoding/hex"
 	"math/big"
 	mathrand "math/rand"
@@ -126,6 +128,52 @@ func TestScalarSetUniformBytes(t *testing.T) {
 	}
 }
 
+func TestScalarSetRandom(t *testing.T) {
+	f := func(in [64]byte) bool {
+		want, _ := new(Scalar).SetUniformBytes(in[:])
+		got, err := new(Scalar).SetRandom(bytes.NewReader(in[:]))
+		return err == nil && got.Equal(want) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	s := new(Scalar).Set(dalekScalar)
+	if out, err := s.SetRandom(bytes.NewReader(make([]byte, 63))); err == nil || out != nil {
+		t.Error("expected error for short read")
+	}
+	if s.Equal(dalekScalar) != 1 {
+		t.Error("receiver was modified on error")
+	}
+
+	a, err1 := new(Scalar).SetRandom(rand.Reader)
+	b, err2 := new(Scalar).SetRandom(rand.Reader)
+	if err1 != nil || err2 != nil || a.Equal(b) == 1 {
+		t.Error("crypto/rand produced equal scalars")
+	}
+}
+
+func TestScalarSetWideBytes(t *testing.T) {
+	f := func(x, y Scalar) bool {
+		// Compute the full 512-bit product of x and y.
+		xBig := bigIntFromLittleEndianBytes(x.Bytes())
+		yBig := bigIntFromLittleEndianBytes(y.Bytes())
+		var product [64]byte
+		xBig.Mul(xBig, yBig).FillBytes(product[:])
+		swapEndianness(product[:])
+
+		var s, check Scalar
+		if out := s.SetWideBytes(&product); out != &s {
+			return false
+		}
+		check.Multiply(&x, &y)
+		return s == check && isReduced(s.Bytes())
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+}
+
 func TestScalarSetBytesWithClamping(t *testing.T) {
 	// Generated with libsodium.js 1.0.18 crypto_scalarmult_ed25519_base.
 
@@ -154,6 +202,13 @@ func TestScalarSetBytesWithClamping(t *testing.T) {
 	}
 }
 
+func swapEndianness(buf []byte) []byte {
+	for i := 0; i < len(buf)/2; i++ {
+		buf[i], buf[len(buf)-i-1] = buf[len(buf)-i-1], buf[i]
+	}
+	return buf
+}
+
 func bigIntFromLittleEndianBytes(b []byte) *big.Int {
 	bb := make([]byte, len(b))
 	for i := range b {
@@ -253,3 +308,304 @@ func TestScalarEqual(t *testing.T) {
 		t.Errorf("scMinusOne.Equal(&scMinusOne) is false")
 	}
 }
+
+func TestScalarCmpVarTime(t *testing.T) {
+	f := func(x, y Scalar) bool {
+		want := x.BigInt().Cmp(y.BigInt())
+		return x.CmpVarTime(&y) == want && y.CmpVarTime(&x) == -want && x.CmpVarTime(&x) == 0
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if scMinusOne.CmpVarTime(scOne) != 1 || NewScalar().CmpVarTime(scOne) != -1 {
+		t.Error("CmpVarTime did not compare canonical values")
+	}
+}
+
+func TestScalarLessThanBytes(t *testing.T) {
+	f := func(x Scalar, bound []byte) bool {
+		want := 0
+		if bigIntFromLittleEndianBytes(x.Bytes()).Cmp(bigIntFromLittleEndianBytes(bound)) < 0 {
+			want = 1
+		}
+		return x.LessThanBytes(bound) == want
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	one := scOneBytes[:]
+	for _, tt := range []struct {
+		s     *Scalar
+		bound []byte
+		want  int
+	}{
+		{scOne, one, 0},
+		{scOne, []byte{2}, 1},
+		{scOne, nil, 0},
+		{NewScalar(), nil, 0},
+		{NewScalar(), one, 1},
+		{scMinusOne, scalarMinusOneBytes[:], 0},
+		{scMinusOne, append(make([]byte, 32), 1), 1},
+		{scMinusOne, append(scalarMinusOneBytes[:], 0, 0, 0), 0},
+	} {
+		if got := tt.s.LessThanBytes(tt.bound); got != tt.want {
+			t.Errorf("%x.LessThanBytes(%x) = %d, want %d", tt.s.Bytes(), tt.bound, got, tt.want)
+		}
+	}
+}
+
+func TestScalarWideBytes(t *testing.T) {
+	f := func(x Scalar) bool {
+		out := x.WideBytes()
+		if len(out) != 64 || !bytes.Equal(out[:32], x.Bytes()) ||
+			!bytes.Equal(out[32:], make([]byte, 32)) {
+			return false
+		}
+		y, err := new(Scalar).SetUniformBytes(out)
+		return err == nil && y.Equal(&x) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestScalarLowBits(t *testing.T) {
+	f := func(x Scalar) bool {
+		b := x.Bytes()
+		low := binary.LittleEndian.Uint64(b[:8])
+		if x.IsOdd() != int(b[0]&1) || x.LowBits(64) != low || x.LowBits(0) != 0 {
+			return false
+		}
+		for n := 1; n < 64; n++ {
+			if x.LowBits(n) != low&(1<<n-1) {
+				return false
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if scOne.IsOdd() != 1 || scMinusOne.IsOdd() != 0 || NewScalar().IsOdd() != 0 {
+		t.Error("IsOdd returned the wrong value for a constant")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("LowBits did not panic on n > 64")
+		}
+	}()
+	scOne.LowBits(65)
+}
+
+func TestScalarSetBytes(t *testing.T) {
+	f := func(in [64]byte) bool {
+		// ReductionWide matches SetUniformBytes.
+		want, _ := new(Scalar).SetUniformBytes(in[:])
+		if got, err := new(Scalar).SetBytes(in[:], ReductionWide); err != nil || got.Equal(want) != 1 {
+			return false
+		}
+
+		// ReductionModOrder reduces a 256-bit integer.
+		var wide [64]byte
+		copy(wide[:], in[:32])
+		want.SetWideBytes(&wide)
+		if got, err := new(Scalar).SetBytes(in[:32], ReductionModOrder); err != nil || got.Equal(want) != 1 {
+			return false
+		}
+
+		// ReductionCanonical matches SetCanonicalBytes.
+		want, wantErr := new(Scalar).SetCanonicalBytes(in[:32])
+		got, err := new(Scalar).SetBytes(in[:32], ReductionCanonical)
+		if (err == nil) != (wantErr == nil) || (err == nil && got.Equal(want) != 1) {
+			return false
+		}
+
+		// Lengths are checked.
+		_, err1 := new(Scalar).SetBytes(in[:], ReductionCanonical)
+		_, err2 := new(Scalar).SetBytes(in[:], ReductionModOrder)
+		_, err3 := new(Scalar).SetBytes(in[:32], ReductionWide)
+		return err1 != nil && err2 != nil && err3 != nil
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := new(Scalar).SetBytes(scMinusOne.Bytes(), ReductionCanonical); err != nil {
+		t.Error("l - 1 was rejected as non-canonical")
+	}
+	allOnes := bytes.Repeat([]byte{0xff}, 32)
+	if _, err := new(Scalar).SetBytes(allOnes, ReductionCanonical); err == nil {
+		t.Error("2^256 - 1 was accepted as canonical")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("invalid ReductionMode did not panic")
+		}
+	}()
+	new(Scalar).SetBytes(allOnes, ReductionMode(42))
+}
+
+func TestScalarBigEndian(t *testing.T) {
+	f := func(x Scalar, in [64]byte) bool {
+		le := x.Bytes()
+		be := x.BytesBE()
+		if !bytes.Equal(be, swapEndianness(le)) {
+			return false
+		}
+		if y, err := new(Scalar).SetBytesBE(be, ReductionCanonical); err != nil || y.Equal(&x) != 1 {
+			return false
+		}
+
+		for _, n := range []int{32, 64} {
+			for _, mode := range []ReductionMode{ReductionCanonical, ReductionModOrder, ReductionWide} {
+				leIn := swapEndianness(append([]byte{}, in[:n]...))
+				want, wantErr := new(Scalar).SetBytes(leIn, mode)
+				got, err := new(Scalar).SetBytesBE(in[:n], mode)
+				if (err == nil) != (wantErr == nil) || (err == nil && got.Equal(want) != 1) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := new(Scalar).SetBytesBE(make([]byte, 65), ReductionWide); err == nil {
+		t.Error("65-byte input was accepted")
+	}
+}
+
+func TestScalarBigInt(t *testing.T) {
+	f := func(x Scalar, in [64]byte, neg bool) bool {
+		b := x.BigInt()
+		if b.Cmp(bigIntFromLittleEndianBytes(x.Bytes())) != 0 {
+			return false
+		}
+		if y, err := new(Scalar).SetBigInt(b, ReductionCanonical); err != nil || y.Equal(&x) != 1 {
+			return false
+		}
+
+		// Any integer is reduced by ReductionModOrder and ReductionWide.
+		n := bigIntFromLittleEndianBytes(in[:])
+		want, _ := new(Scalar).SetUniformBytes(in[:])
+		if neg {
+			n.Neg(n)
+			want.Negate(want)
+		}
+		for _, mode := range []ReductionMode{ReductionModOrder, ReductionWide} {
+			if got, err := new(Scalar).SetBigInt(n, mode); err != nil || got.Equal(want) != 1 {
+				return false
+			}
+		}
+		_, err := new(Scalar).SetBigInt(n, ReductionCanonical)
+		return (err == nil) == (n.Sign() >= 0 && n.Cmp(scalarOrder) < 0)
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if scMinusOne.BigInt().Cmp(new(big.Int).Sub(scalarOrder, big.NewInt(1))) != 0 {
+		t.Error("l - 1 has the wrong value")
+	}
+	if _, err := new(Scalar).SetBigInt(scalarOrder, ReductionCanonical); err == nil {
+		t.Error("l was accepted as canonical")
+	}
+	if _, err := new(Scalar).SetBigInt(big.NewInt(-1), ReductionCanonical); err == nil {
+		t.Error("-1 was accepted as canonical")
+	}
+}
+
+func TestScalarFillBytes(t *testing.T) {
+	f := func(x Scalar, pad uint8) bool {
+		buf := make([]byte, 32+int(pad%64))
+		for i := range buf {
+			buf[i] = 0xff
+		}
+		out := x.FillBytes(buf)
+		if &out[0] != &buf[0] || len(out) != len(buf) {
+			return false
+		}
+		want := append(x.Bytes(), make([]byte, len(buf)-32)...)
+		return bytes.Equal(out, want)
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("FillBytes did not panic on a short buffer")
+		}
+	}()
+	scOne.FillBytes(make([]byte, 31))
+}
+
+func TestScalarLowHalf(t *testing.T) {
+	mod, _ := new(big.Int).SetString("27742317777372353535851937790883648493", 10)
+	mod.Add(mod, new(big.Int).Lsh(big.NewInt(1), 252))
+	half := new(big.Int).Rsh(mod, 1) // (l - 1) / 2
+	if got := bigIntFromLittleEndianBytes(scalarHalfOrderBytes[:]); got.Cmp(half.Add(half, big.NewInt(1))) != 0 {
+		t.Fatalf("scalarHalfOrderBytes is %v, expected %v", got, half)
+	}
+	half.Sub(half, big.NewInt(1))
+
+	f := func(x Scalar) bool {
+		xBig := bigIntFromLittleEndianBytes(x.Bytes())
+		isLow := xBig.Cmp(half) <= 0
+		if (x.IsLowHalf() == 1) != isLow {
+			return false
+		}
+		var s Scalar
+		s.SetLowHalf(&x)
+		if s.IsLowHalf() != 1 {
+			return false
+		}
+		if isLow {
+			return s == x
+		}
+		return s == *new(Scalar).Negate(&x)
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	if scMinusOne.IsLowHalf() != 0 || scOne.IsLowHalf() != 1 || NewScalar().IsLowHalf() != 1 {
+		t.Error("IsLowHalf is wrong on edge values")
+	}
+	if s := NewScalar().SetLowHalf(scMinusOne); s.Equal(scOne) != 1 {
+		t.Error("SetLowHalf(-1) != 1")
+	}
+}
+
+func TestScalarSignedDigits(t *testing.T) {
+	for c := uint(2); c <= pippengerMaxWindow; c++ {
+		f := func(x Scalar) bool {
+			digits := make([]int32, (256+c-1)/c)
+			x.signedDigits(digits, c)
+
+			half := int32(1) << (c - 1)
+			got := new(big.Int)
+			for j := len(digits) - 1; j >= 0; j-- {
+				if digits[j] > half || digits[j] < -half {
+					return false
+				}
+				got.Lsh(got, c)
+				got.Add(got, big.NewInt(int64(digits[j])))
+			}
+			return got.Cmp(bigIntFromLittleEndianBytes(x.Bytes())) == 0
+		}
+		if err := quick.Check(f, quickCheckConfig(64)); err != nil {
+			t.Errorf("c = %d: %v", c, err)
+		}
+		if !f(*scMinusOne) {
+			t.Errorf("c = %d: failed for l - 1", c)
+		}
+	}
+}
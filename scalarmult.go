@@ -4,25 +4,52 @@
 
 package edwards25519
 
-import "sync"
+import (
+	"crypto/subtle"
+	"sync"
 
-// basepointTable is a set of 32 affineLookupTables, where table i is generated
-// from 256i * basepoint. It is precomputed the first time it's used.
-func basepointTable() *[32]affineLookupTable {
+	"filippo.io/edwards25519/field"
+)
+
+// basepointTable is a set of basepointCombTables affineLookupTables, where
+// table i is generated from 16^(s*i) * basepoint, with s = 64 /
+// basepointCombTables. It is precomputed the first time it's used.
+func basepointTable() *[basepointCombTables]affineLookupTable {
 	basepointTablePrecomp.initOnce.Do(func() {
-		p := NewGeneratorPoint()
-		for i := 0; i < 32; i++ {
-			basepointTablePrecomp.table[i].FromP3(p)
-			for j := 0; j < 8; j++ {
-				p.Add(p, p)
-			}
-		}
+		fillCombTable(basepointTablePrecomp.table[:], generator, 4*basepointCombSpacing)
 	})
 	return &basepointTablePrecomp.table
 }
 
+// basepointCombSpacing is the number of radix 16 digits between the tables of
+// basepointTable.
+const basepointCombSpacing = 64 / basepointCombTables
+
+// fillCombTable sets table[i] to the affineLookupTable of 2^(shift*i) * q, as
+// used by ScalarBaseMult.
+func fillCombTable(table []affineLookupTable, q *Point, shift int) {
+	// Compute all the multiples in projective coordinates first, and then
+	// convert them to affine coordinates with a single field inversion.
+	points := make([]Point, len(table)*8)
+	p := new(Point).Set(q)
+	for i := range table {
+		fillMultiples(points[8*i:8*(i+1)], p, p)
+		p.MultByPow2(p, shift)
+	}
+
+	zs := make([]field.Element, 2*len(points))
+	zInvs, products := zs[:len(points)], zs[len(points):]
+	for i := range points {
+		zInvs[i].Set(&points[i].z)
+	}
+	invertAll(zInvs, zInvs, products)
+	for i := range points {
+		table[i/8].points[i%8].fromP3ZInv(&points[i], &zInvs[i])
+	}
+}
+
 var basepointTablePrecomp struct {
-	table    [32]affineLookupTable
+	table    [basepointCombTables]affineLookupTable
 	initOnce sync.Once
 }
 
@@ -30,50 +57,47 @@ var basepointTablePrecomp struct {
 // returns v.
 //
 // The scalar multiplication is done in constant time.
+//
+// The first call precomputes a 30KB table. Programs built with the
+// edwards25519_smalltables tag use a 7.5KB table instead, at the cost of 24
+// more doublings per call, about 20% slower. Programs built with the
+// edwards25519_notables tag for memory-constrained targets skip the table and
+// compute each product like ScalarMult. Other variable-time and multi-scalar
+// APIs still use precomputed tables.
 func (v *Point) ScalarBaseMult(x *Scalar) *Point {
+	if !useBasepointTable {
+		return v.ScalarMult(x, generator)
+	}
 	basepointTable := basepointTable()
 
 	// Write x = sum(x_i * 16^i) so  x*B = sum( B*x_i*16^i )
 	// as described in the Ed25519 paper
 	//
-	// Group even and odd coefficients
+	// Group the coefficients by their index modulo s = basepointCombSpacing,
+	// which is 2 for the default table, splitting even and odd coefficients
 	// x*B     = x_0*16^0*B + x_2*16^2*B + ... + x_62*16^62*B
 	//         + x_1*16^1*B + x_3*16^3*B + ... + x_63*16^63*B
 	// x*B     = x_0*16^0*B + x_2*16^2*B + ... + x_62*16^62*B
 	//    + 16*( x_1*16^0*B + x_3*16^2*B + ... + x_63*16^62*B)
 	//
-	// We use a lookup table for each i to get x_i*16^(2*i)*B
+	// We use a lookup table for each j to get x_(s*j+r)*16^(s*j)*B
 	// and do four doublings to multiply by 16.
 	digits := x.signedRadix16()
 
 	multiple := &affineCached{}
 	tmp1 := &projP1xP1{}
-	tmp2 := &projP2{}
 
-	// Accumulate the odd components first
 	v.Set(NewIdentityPoint())
-	for i := 1; i < 64; i += 2 {
-		basepointTable[i/2].SelectInto(multiple, digits[i])
-		tmp1.AddAffine(v, multiple)
-		v.fromP1xP1(tmp1)
-	}
-
-	// Multiply by 16
-	tmp2.FromP3(v)       // tmp2 =    v in P2 coords
-	tmp1.Double(tmp2)    // tmp1 =  2*v in P1xP1 coords
-	tmp2.FromP1xP1(tmp1) // tmp2 =  2*v in P2 coords
-	tmp1.Double(tmp2)    // tmp1 =  4*v in P1xP1 coords
-	tmp2.FromP1xP1(tmp1) // tmp2 =  4*v in P2 coords
-	tmp1.Double(tmp2)    // tmp1 =  8*v in P1xP1 coords
-	tmp2.FromP1xP1(tmp1) // tmp2 =  8*v in P2 coords
-	tmp1.Double(tmp2)    // tmp1 = 16*v in P1xP1 coords
-	v.fromP1xP1(tmp1)    // now v = 16*(odd components)
-
-	// Accumulate the even components
-	for i := 0; i < 64; i += 2 {
-		basepointTable[i/2].SelectInto(multiple, digits[i])
-		tmp1.AddAffine(v, multiple)
-		v.fromP1xP1(tmp1)
+	for r := basepointCombSpacing - 1; r >= 0; r-- {
+		// Accumulate the components with index r modulo s
+		for j := range basepointTable {
+			basepointTable[j].SelectInto(multiple, digits[j*basepointCombSpacing+r])
+			tmp1.AddAffine(v, multiple)
+			v.fromP1xP1(tmp1)
+		}
+		if r > 0 {
+			v.MultByPow2(v, 4)
+		}
 	}
 
 	return v
@@ -122,6 +146,74 @@ func (v *Point) ScalarMult(x *Scalar, q *Point) *Point {
 	return v
 }
 
+// ScalarMultWithWindow sets v = x * q, and returns v, using windows of
+// windowBits bits.
+//
+// ScalarMult uses 4-bit windows, with a table of 8 multiples of q. A window of
+// w bits needs a table of 2^(w-1) multiples, which take 160 bytes each, and
+// ceil(256 / w) additions. Smaller windows save memory at the cost of speed,
+// while 4 and 5-bit windows are about equally fast. windowBits must be
+// between 2 and 6, inclusive, or ScalarMultWithWindow will panic. Wider
+// windows don't pay for the cost of building their table; use a
+// PrecomputedPoint to multiply a fixed point by many scalars.
+//
+// The scalar multiplication is done in constant time.
+func (v *Point) ScalarMultWithWindow(x *Scalar, q *Point, windowBits int) *Point {
+	checkInitialized(q)
+	if windowBits < 2 || windowBits > 6 {
+		panic("edwards25519: invalid ScalarMult window size")
+	}
+	w := uint(windowBits)
+
+	// table[j] = (j + 1) * q
+	var tableBuf [32]projCached
+	table := tableBuf[:1<<(w-1)]
+	table[0].FromP3(q)
+	var p Point
+	p.Set(q)
+	tmp1 := &projP1xP1{}
+	for j := 1; j < len(table); j++ {
+		tmp1.Add(&p, &table[0])
+		p.fromP1xP1(tmp1)
+		table[j].FromP3(&p)
+	}
+
+	var digitsBuf [128]int32
+	digits := digitsBuf[:(256+windowBits-1)/windowBits]
+	x.signedDigits(digits, w)
+
+	multiple := &projCached{}
+	tmp2 := &projP2{}
+	v.Set(NewIdentityPoint())
+	for i := len(digits) - 1; i >= 0; i-- {
+		// Multiply the accumulator by 2^w, unless it's still the identity.
+		if i != len(digits)-1 {
+			tmp2.FromP3(v)
+			for k := uint(1); k < w; k++ {
+				tmp1.Double(tmp2)
+				tmp2.FromP1xP1(tmp1)
+			}
+			tmp1.Double(tmp2)
+			v.fromP1xP1(tmp1)
+		}
+
+		// Compute |d| and set multiple = |d| * q in constant time.
+		d := digits[i]
+		mask := d >> 31
+		abs := (d ^ mask) - mask
+		multiple.Zero()
+		for j := range table {
+			cond := subtle.ConstantTimeEq(abs, int32(j+1))
+			multiple.Select(&table[j], multiple, cond)
+		}
+		multiple.CondNeg(int(mask & 1))
+
+		tmp1.Add(v, multiple)
+		v.fromP1xP1(tmp1)
+	}
+	return v
+}
+
 // basepointNafTable is the nafLookupTable8 for the basepoint.
 // It is precomputed the first time it's used.
 func basepointNafTable() *nafLookupTable8 {
@@ -157,9 +249,15 @@ func (v *Point) VarTimeDoubleScalarBaseMult(a *Scalar, A *Point, b *Scalar) *Poi
 	// "mass" of the scalar onto sparse coefficients (meaning
 	// fewer additions).
 
-	basepointNafTable := basepointNafTable()
 	var aTable nafLookupTable5
 	aTable.FromP3(A)
+	return v.varTimeDoubleScalarBaseMult(a, &aTable, b)
+}
+
+// varTimeDoubleScalarBaseMult works like VarTimeDoubleScalarBaseMult, but takes
+// a precomputed table for A.
+func (v *Point) varTimeDoubleScalarBaseMult(a *Scalar, aTable *nafLookupTable5, b *Scalar) *Point {
+	basepointNafTable := basepointNafTable()
 	// Because the basepoint is fixed, we can use a wider NAF
 	// corresponding to a bigger table.
 	aNaf := a.nonAdjacentForm(5)
@@ -167,8 +265,8 @@ func (v *Point) VarTimeDoubleScalarBaseMult(a *Scalar, A *Point, b *Scalar) *Poi
 
 	// Find the first nonzero coefficient.
 	i := 255
-	for j := i; j >= 0; j-- {
-		if aNaf[j] != 0 || bNaf[j] != 0 {
+	for ; i >= 0; i-- {
+		if aNaf[i] != 0 || bNaf[i] != 0 {
 			break
 		}
 	}
@@ -0,0 +1,12 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !edwards25519_smalltables
+
+package edwards25519
+
+// basepointCombTables is the number of affineLookupTables in basepointTable,
+// which take 960 bytes each. It's reduced from 32 to 8 by the
+// edwards25519_smalltables build tag.
+const basepointCombTables = 32
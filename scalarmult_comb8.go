@@ -0,0 +1,13 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_smalltables
+
+package edwards25519
+
+// basepointCombTables is the number of affineLookupTables in basepointTable,
+// which take 960 bytes each. With the edwards25519_smalltables build tag, the
+// tables are 2^32 apart instead of 2^8, and ScalarBaseMult does 28 doublings
+// instead of 4.
+const basepointCombTables = 8
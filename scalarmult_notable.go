@@ -0,0 +1,13 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_notables
+
+package edwards25519
+
+// useBasepointTable reports whether ScalarBaseMult uses the precomputed
+// basepointTable. With the edwards25519_notables build tag, ScalarBaseMult
+// instead computes a small table of multiples of the generator on the stack
+// for each call, and is about three to four times slower.
+const useBasepointTable = false
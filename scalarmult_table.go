@@ -0,0 +1,11 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !edwards25519_notables
+
+package edwards25519
+
+// useBasepointTable reports whether ScalarBaseMult uses the precomputed
+// basepointTable. It's disabled by the edwards25519_notables build tag.
+const useBasepointTable = true
@@ -5,6 +5,7 @@
 package edwards25519
 
 import (
+	"strconv"
 	"testing"
 	"testing/quick"
 )
@@ -106,17 +107,48 @@ func TestScalarMultNonIdentityPoint(t *testing.T) {
 	}
 }
 
+func TestScalarMultWithWindow(t *testing.T) {
+	f := func(x Scalar) bool {
+		var check Point
+		check.ScalarMult(&x, dalekScalarBasepoint)
+		for w := 2; w <= 6; w++ {
+			p := new(Point).ScalarMultWithWindow(&x, dalekScalarBasepoint, w)
+			checkOnCurve(t, p)
+			if p.Equal(&check) != 1 {
+				return false
+			}
+		}
+		// The receiver may alias the input point.
+		q := new(Point).Set(dalekScalarBasepoint)
+		return q.ScalarMultWithWindow(&x, q, 5).Equal(&check) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(32)); err != nil {
+		t.Error(err)
+	}
+
+	for _, w := range []int{0, 1, 7} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("window size %d did not panic", w)
+				}
+			}()
+			new(Point).ScalarMultWithWindow(scOne, B, w)
+		}()
+	}
+}
+
 func TestBasepointTableGeneration(t *testing.T) {
-	// The basepoint table is 32 affineLookupTables,
-	// corresponding to (16^2i)*B for table i.
+	// The basepoint table is basepointCombTables affineLookupTables,
+	// corresponding to (16^(s*i))*B for table i, with s = basepointCombSpacing.
 	basepointTable := basepointTable()
 
 	tmp1 := &projP1xP1{}
 	tmp2 := &projP2{}
 	tmp3 := &Point{}
 	tmp3.Set(B)
-	table := make([]affineLookupTable, 32)
-	for i := 0; i < 32; i++ {
+	table := make([]affineLookupTable, basepointCombTables)
+	for i := 0; i < basepointCombTables; i++ {
 		// Build the table
 		table[i].FromP3(tmp3)
 		// Assert equality with the hardcoded one
@@ -124,9 +156,9 @@ func TestBasepointTableGeneration(t *testing.T) {
 			t.Errorf("Basepoint table %d does not match", i)
 		}
 
-		// Set p = (16^2)*p = 256*p = 2^8*p
+		// Set p = (16^s)*p = 2^(4*s)*p
 		tmp2.FromP3(tmp3)
-		for j := 0; j < 7; j++ {
+		for j := 0; j < 4*basepointCombSpacing-1; j++ {
 			tmp1.Double(tmp2)
 			tmp2.FromP1xP1(tmp1)
 		}
@@ -203,3 +235,14 @@ func BenchmarkVarTimeDoubleScalarBaseMult(b *testing.B) {
 		p.VarTimeDoubleScalarBaseMult(dalekScalar, B, dalekScalar)
 	}
 }
+
+func BenchmarkScalarMultWithWindow(b *testing.B) {
+	for w := 2; w <= 6; w++ {
+		b.Run(strconv.Itoa(w), func(b *testing.B) {
+			var p Point
+			for i := 0; i < b.N; i++ {
+				p.ScalarMultWithWindow(dalekScalar, B, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"errors"
+)
+
+// Known answer vector for SelfTest, a random scalar generated using dalek and
+// the encoding of its product with the canonical generator.
+var (
+	selfTestScalar = []byte{219, 106, 114, 9, 174, 249, 155, 89, 69, 203, 201, 93, 92, 116, 234, 187, 78, 115, 103, 172, 182, 98, 62, 103, 187, 136, 13, 100, 248, 110, 12, 4}
+	selfTestPoint  = []byte{0xf4, 0xef, 0x7c, 0xa, 0x34, 0x55, 0x7b, 0x9f, 0x72, 0x3b, 0xb6, 0x1e, 0xf9, 0x46, 0x9, 0x91, 0x1c, 0xb9, 0xc0, 0x6c, 0x17, 0x28, 0x2d, 0x8b, 0x43, 0x2b, 0x5, 0x18, 0x6a, 0x54, 0x3e, 0x48}
+)
+
+// SelfTest runs a quick battery of known answer tests and group law checks,
+// and returns an error describing the first failure, if any. It is intended
+// for power-on self-tests, such as those required by FIPS 140, and takes
+// roughly the time of a few scalar multiplications.
+//
+// SelfTest exercises the scalar multiplication, addition, and encoding paths
+// of the package, including any assembly backends. It does not replace the
+// package tests.
+func SelfTest() error {
+	s, err := new(Scalar).SetCanonicalBytes(selfTestScalar)
+	if err != nil {
+		return errors.New("edwards25519: self-test failed: invalid test scalar")
+	}
+	want, err := new(Point).SetBytes(selfTestPoint)
+	if err != nil {
+		return errors.New("edwards25519: self-test failed: point decoding")
+	}
+
+	// Known answer tests for each scalar multiplication strategy.
+	p := new(Point).ScalarBaseMult(s)
+	if !bytes.Equal(p.Bytes(), selfTestPoint) {
+		return errors.New("edwards25519: self-test failed: ScalarBaseMult")
+	}
+	if new(Point).ScalarMult(s, generator).Equal(want) != 1 {
+		return errors.New("edwards25519: self-test failed: ScalarMult")
+	}
+	if new(Point).VarTimeDoubleScalarBaseMult(s, generator, NewScalar()).Equal(want) != 1 {
+		return errors.New("edwards25519: self-test failed: VarTimeDoubleScalarBaseMult")
+	}
+
+	// Associativity and commutativity: (P + Q) + B = P + (B + Q).
+	q := new(Point).Double(p)
+	lhs := new(Point).Add(p, q)
+	lhs.Add(lhs, generator)
+	rhs := new(Point).Add(generator, q)
+	rhs.Add(p, rhs)
+	if lhs.Equal(rhs) != 1 {
+		return errors.New("edwards25519: self-test failed: associativity")
+	}
+
+	// Inverses: P - P = P + (-P) = 0.
+	if new(Point).Subtract(p, p).Equal(identity) != 1 ||
+		new(Point).Add(p, new(Point).Negate(p)).Equal(identity) != 1 {
+		return errors.New("edwards25519: self-test failed: negation")
+	}
+
+	// Order of the prime order subgroup: (l - 1) * B + B = 0.
+	order := new(Point).ScalarBaseMult(scalarMinusOne)
+	if order.Add(order, generator).Equal(identity) != 1 {
+		return errors.New("edwards25519: self-test failed: generator order")
+	}
+
+	// Order of the torsion subgroup: 8 * T = 0, 4 * T != 0.
+	t := SmallOrderPoints()[6]
+	if new(Point).MultByPow2(t, 2).Equal(identity) != 0 ||
+		new(Point).MultByCofactor(t).Equal(identity) != 1 {
+		return errors.New("edwards25519: self-test failed: torsion order")
+	}
+
+	// Encoding round-trip.
+	if rt, err := new(Point).SetBytes(q.Bytes()); err != nil || rt.Equal(q) != 1 {
+		return errors.New("edwards25519: self-test failed: encoding round-trip")
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSelfTest(t *testing.T) {
+	if err := SelfTest(); err != nil {
+		t.Fatal(err)
+	}
+
+	// The known answer vector must match the one used by the other tests.
+	if !bytes.Equal(selfTestScalar, dalekScalar.Bytes()) ||
+		!bytes.Equal(selfTestPoint, dalekScalarBasepoint.Bytes()) {
+		t.Error("self-test vector does not match dalekScalar")
+	}
+}
+
+func BenchmarkSelfTest(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		if err := SelfTest(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
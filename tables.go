@@ -6,6 +6,8 @@ package edwards25519
 
 import (
 	"crypto/subtle"
+
+	"filippo.io/edwards25519/field"
 )
 
 // A dynamic lookup table for variable-base, constant-time scalar muls.
@@ -45,17 +47,15 @@ func (v *projLookupTable) FromP3(q *Point) {
 	}
 }
 
-// This is not optimised for speed; fixed-base tables should be precomputed.
+// Builds a lookup table at runtime. Slow, because of the field inversion;
+// fixed-base tables should be precomputed.
 func (v *affineLookupTable) FromP3(q *Point) {
 	// Goal: v.points[i] = (i+1)*Q, i.e., Q, 2Q, ..., 8Q
 	// This allows lookup of -8Q, ..., -Q, 0, Q, ..., 8Q
-	v.points[0].FromP3(q)
-	tmpP3 := Point{}
-	tmpP1xP1 := projP1xP1{}
-	for i := 0; i < 7; i++ {
-		// Compute (i+1)*Q as Q + i*Q and convert to affineCached
-		v.points[i+1].FromP3(tmpP3.fromP1xP1(tmpP1xP1.AddAffine(q, &v.points[i])))
-	}
+	var points [8]Point
+	var zs [16]field.Element
+	fillMultiples(points[:], q, q)
+	affineCachedFromP3Batch(v.points[:], points[:], zs[:])
 }
 
 // Builds a lookup table at runtime. Fast.
@@ -72,15 +72,40 @@ func (v *nafLookupTable5) FromP3(q *Point) {
 	}
 }
 
-// This is not optimised for speed; fixed-base tables should be precomputed.
+// Builds a lookup table at runtime. Slow, because of the field inversion;
+// fixed-base tables should be precomputed.
 func (v *nafLookupTable8) FromP3(q *Point) {
-	v.points[0].FromP3(q)
+	// Goal: v.points[i] = (2*i+1)*Q, i.e., Q, 3Q, 5Q, ..., 127Q
 	q2 := Point{}
 	q2.Add(q, q)
-	tmpP3 := Point{}
+	var points [64]Point
+	var zs [128]field.Element
+	fillMultiples(points[:], q, &q2)
+	affineCachedFromP3Batch(v.points[:], points[:], zs[:])
+}
+
+// fillMultiples sets points[i] = q + i*step.
+func fillMultiples(points []Point, q, step *Point) {
+	var stepCached projCached
+	stepCached.FromP3(step)
 	tmpP1xP1 := projP1xP1{}
-	for i := 0; i < 63; i++ {
-		v.points[i+1].FromP3(tmpP3.fromP1xP1(tmpP1xP1.AddAffine(&q2, &v.points[i])))
+	points[0].Set(q)
+	for i := 1; i < len(points); i++ {
+		points[i].fromP1xP1(tmpP1xP1.Add(&points[i-1], &stepCached))
+	}
+}
+
+// affineCachedFromP3Batch sets out[i] to the affineCached form of points[i],
+// using a single field inversion. zs is scratch space of 2 * len(points)
+// elements.
+func affineCachedFromP3Batch(out []affineCached, points []Point, zs []field.Element) {
+	zInvs, products := zs[:len(points)], zs[len(points):]
+	for i := range points {
+		zInvs[i].Set(&points[i].z)
+	}
+	invertAll(zInvs, zInvs, products)
+	for i := range points {
+		out[i].fromP3ZInv(&points[i], &zInvs[i])
 	}
 }
 
@@ -93,9 +118,11 @@ func (v *projLookupTable) SelectInto(dest *projCached, x int8) {
 	xabs := uint8((x + xmask) ^ xmask)
 
 	dest.Zero()
-	for j := 1; j <= 8; j++ {
-		// Set dest = j*Q if |x| = j
-		cond := subtle.ConstantTimeByteEq(xabs, uint8(j))
+	order := selectShuffleOrder()
+	for _, o := range order {
+		// Set dest = j*Q if |x| = j, scanning j in the order set by order
+		j := o + 1
+		cond := subtle.ConstantTimeByteEq(xabs, j)
 		dest.Select(&v.points[j-1], dest, cond)
 	}
 	// Now dest = |x|*Q, conditionally negate to get x*Q
@@ -109,9 +136,11 @@ func (v *affineLookupTable) SelectInto(dest *affineCached, x int8) {
 	xabs := uint8((x + xmask) ^ xmask)
 
 	dest.Zero()
-	for j := 1; j <= 8; j++ {
-		// Set dest = j*Q if |x| = j
-		cond := subtle.ConstantTimeByteEq(xabs, uint8(j))
+	order := selectShuffleOrder()
+	for _, o := range order {
+		// Set dest = j*Q if |x| = j, scanning j in the order set by order
+		j := o + 1
+		cond := subtle.ConstantTimeByteEq(xabs, j)
 		dest.Select(&v.points[j-1], dest, cond)
 	}
 	// Now dest = |x|*Q, conditionally negate to get x*Q
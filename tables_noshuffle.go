@@ -0,0 +1,12 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !edwards25519_shuffle
+
+package edwards25519
+
+// selectShuffleOrder returns the order in which the constant-time SelectInto
+// methods scan the eight table entries, as a permutation of 0 to 7. Without
+// the edwards25519_shuffle build tag, tables are always scanned in order.
+func selectShuffleOrder() [8]uint8 { return [8]uint8{0, 1, 2, 3, 4, 5, 6, 7} }
@@ -0,0 +1,43 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_shuffle
+
+package edwards25519
+
+import (
+	"math/bits"
+	"math/rand"
+)
+
+// With the edwards25519_shuffle build tag, the constant-time SelectInto
+// methods scan the table entries in a fresh random order on every call. Every
+// entry is still read exactly once, so this is a mitigation against residual
+// leakage through cache banks and prefetchers on shared hardware, on top of
+// the regular constant-time selection, and not a replacement for it.
+//
+// The randomness comes from the top-level math/rand functions, which are
+// backed by per-thread runtime state, so concurrent scalar multiplications
+// don't contend on any shared memory. This is not a CSPRNG, but the order
+// only needs to be unpredictable from the outside. Programs that call the
+// deprecated rand.Seed on Go versions where it's effective make the order
+// predictable.
+
+// selectShuffleOrder returns the order in which the constant-time SelectInto
+// methods scan the eight table entries, as a permutation of 0 to 7.
+func selectShuffleOrder() [8]uint8 {
+	order := [8]uint8{0, 1, 2, 3, 4, 5, 6, 7}
+
+	// Fisher-Yates, drawing each index j in [0, i] from the high half of
+	// r * (i + 1), and keeping the low half as the randomness for the next
+	// draw. A single 64-bit value is enough for the 8! ≈ 2^15.3 permutations,
+	// with negligible bias.
+	r := rand.Uint64()
+	for i := 7; i > 0; i-- {
+		hi, lo := bits.Mul64(r, uint64(i+1))
+		r = lo
+		order[i], order[hi] = order[hi], order[i]
+	}
+	return order
+}
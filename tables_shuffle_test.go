@@ -0,0 +1,39 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build edwards25519_shuffle
+
+package edwards25519
+
+import "testing"
+
+func TestSelectShuffleOrder(t *testing.T) {
+	// Every order must be a permutation, and every entry must show up in
+	// every position about as often.
+	var seen [8][8]int
+	orders := make(map[[8]uint8]bool)
+	for i := 0; i < 80000; i++ {
+		order := selectShuffleOrder()
+		var used [8]bool
+		for pos, j := range order {
+			if j >= 8 || used[j] {
+				t.Fatalf("%v is not a permutation", order)
+			}
+			used[j] = true
+			seen[pos][j]++
+		}
+		orders[order] = true
+	}
+	for pos := range seen {
+		for j, n := range seen[pos] {
+			if n < 9000 || n > 11000 {
+				t.Errorf("entry %d in position %d %d times out of 80000", j, pos, n)
+			}
+		}
+	}
+	// 80000 draws from 8! = 40320 permutations hit about 35000 of them.
+	if len(orders) < 30000 {
+		t.Errorf("only %d distinct orders out of 80000 draws", len(orders))
+	}
+}
@@ -117,3 +117,10 @@ func TestNafLookupTable8(t *testing.T) {
 		t.Errorf("Consistency check on nafLookupTable8 failed")
 	}
 }
+
+func BenchmarkNafLookupTable8(b *testing.B) {
+	var table nafLookupTable8
+	for i := 0; i < b.N; i++ {
+		table.FromP3(B)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"encoding/hex"
+	"strconv"
+)
+
+// An EdgeCaseEncoding is a valid point encoding that exercises
+// cofactor-related edge cases, such as points of small or mixed order and
+// non-canonical encodings.
+type EdgeCaseEncoding struct {
+	// Name is a short human-readable description of the encoding.
+	Name string
+
+	// Encoding is the 32-byte encoding, which is accepted by SetBytes.
+	Encoding [32]byte
+
+	// Canonical is true if Encoding is the one returned by Bytes for the
+	// decoded point.
+	Canonical bool
+
+	// SmallOrder is true if the point is in the 8-torsion subgroup, that is
+	// if its order divides the cofactor.
+	SmallOrder bool
+
+	// TorsionOrder is the order of the torsion component of the point: 1, 2,
+	// 4, or 8. Points with TorsionOrder 1 are in the prime order subgroup,
+	// while points with TorsionOrder greater than 1 and SmallOrder false are
+	// of mixed order.
+	TorsionOrder int
+}
+
+// smallOrderEncodings are the canonical encodings of the eight points of the
+// 8-torsion subgroup, with their names.
+var smallOrderEncodings = [8]struct{ name, encoding string }{
+	{"identity", "0100000000000000000000000000000000000000000000000000000000000000"},
+	{"order 2", "ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f"},
+	{"order 4,sign+", "0000000000000000000000000000000000000000000000000000000000000000"},
+	{"order 4,sign-", "0000000000000000000000000000000000000000000000000000000000000080"},
+	{"order 8,y=26e8…,sign+", "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05"},
+	{"order 8,y=26e8…,sign-", "26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85"},
+	{"order 8,y=c717…,sign+", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a"},
+	{"order 8,y=c717…,sign-", "c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa"},
+}
+
+// SmallOrderPoints returns newly allocated copies of the eight points of the
+// 8-torsion subgroup, that is, the points whose order divides the cofactor.
+//
+// The first point is the identity, followed by the point of order 2, the two
+// points of order 4, and the four points of order 8.
+func SmallOrderPoints() []*Point {
+	points := make([]*Point, len(smallOrderEncodings))
+	for i, e := range smallOrderEncodings {
+		b, _ := hex.DecodeString(e.encoding)
+		points[i], _ = new(Point).SetBytes(b)
+	}
+	return points
+}
+
+// EdgeCaseEncodings returns a fresh list of point encodings for testing
+// cofactor-related edge cases. It includes
+//
+//   - the canonical encodings of the eight small order points;
+//   - every valid non-canonical encoding, that is, encodings of x = 0 with the
+//     sign bit set, and encodings of y values between p and 2²⁵⁵ - 1;
+//   - canonical encodings of mixed order points, obtained by adding each
+//     non-identity small order point to the canonical generator.
+//
+// The list is computed on every call, in variable time, and is meant to be
+// used in tests of protocols built on this package.
+func EdgeCaseEncodings() []EdgeCaseEncoding {
+	var vectors []EdgeCaseEncoding
+	add := func(name string, encoding []byte) {
+		p, err := new(Point).SetBytes(encoding)
+		if err != nil {
+			return
+		}
+		v := EdgeCaseEncoding{Name: name}
+		copy(v.Encoding[:], encoding)
+		v.Canonical = string(p.Bytes()) == string(encoding)
+		v.SmallOrder = new(Point).MultByCofactor(p).Equal(identity) == 1
+		v.TorsionOrder = torsionOrder(p)
+		vectors = append(vectors, v)
+	}
+
+	torsion := SmallOrderPoints()
+	for i, e := range smallOrderEncodings {
+		add(e.name, torsion[i].Bytes())
+	}
+
+	// Encodings of x = 0 with the sign bit set, for y = 1 and y = -1.
+	for _, e := range smallOrderEncodings[:2] {
+		b, _ := hex.DecodeString(e.encoding)
+		b[31] |= 0x80
+		add(e.name+",x=-0", b)
+	}
+
+	// Encodings of y = p + k for k = 0..18, which are only valid if k is a
+	// valid y-coordinate, with both sign bits.
+	for k := 0; k <= 18; k++ {
+		b, _ := hex.DecodeString(smallOrderEncodings[1].encoding) // p - 1
+		b[0] += byte(k + 1)
+		add("y=p+"+strconv.Itoa(k)+",sign+", b)
+		b[31] |= 0x80
+		add("y=p+"+strconv.Itoa(k)+",sign-", b)
+	}
+
+	for i, tt := range torsion[1:] {
+		p := new(Point).Add(generator, tt)
+		add("B + "+smallOrderEncodings[i+1].name, p.Bytes())
+	}
+
+	return vectors
+}
+
+// torsionOrder returns the order of the torsion component of p, in variable
+// time.
+func torsionOrder(p *Point) int {
+	// l * P = (l - 1) * P + P is the torsion component of P multiplied by l,
+	// which has the same order since l is odd.
+	q := new(Point).VarTimeDoubleScalarBaseMult(scalarMinusOne, p, NewScalar())
+	q.Add(q, p)
+	order := 1
+	for q.Equal(identity) != 1 {
+		q.Add(q, q)
+		order *= 2
+	}
+	return order
+}
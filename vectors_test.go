@@ -0,0 +1,100 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestEdgeCaseEncodings(t *testing.T) {
+	vectors := EdgeCaseEncodings()
+
+	seen := make(map[[32]byte]bool)
+	var smallOrder, nonCanonical, mixedOrder int
+	for _, v := range vectors {
+		if seen[v.Encoding] {
+			t.Errorf("%s: duplicate encoding %x", v.Name, v.Encoding)
+		}
+		seen[v.Encoding] = true
+
+		p, err := new(Point).SetBytes(v.Encoding[:])
+		if err != nil {
+			t.Errorf("%s: invalid encoding: %v", v.Name, err)
+			continue
+		}
+		checkOnCurve(t, p)
+
+		if canonical := hex.EncodeToString(p.Bytes()) == hex.EncodeToString(v.Encoding[:]); canonical != v.Canonical {
+			t.Errorf("%s: Canonical is %v, expected %v", v.Name, v.Canonical, canonical)
+		}
+		if !v.Canonical {
+			nonCanonical++
+		}
+
+		// Check TorsionOrder by multiplying the point by l * TorsionOrder.
+		lP := new(Point).ScalarMult(scMinusOne, p)
+		lP.Add(lP, p)
+		q := new(Point).Set(lP)
+		for i := 1; i < v.TorsionOrder; i *= 2 {
+			if q.Equal(I) == 1 {
+				t.Errorf("%s: TorsionOrder %d is too large", v.Name, v.TorsionOrder)
+			}
+			q.Add(q, q)
+		}
+		if q.Equal(I) != 1 {
+			t.Errorf("%s: TorsionOrder %d is too small", v.Name, v.TorsionOrder)
+		}
+
+		if isSmall := new(Point).MultByCofactor(p).Equal(I) == 1; isSmall != v.SmallOrder {
+			t.Errorf("%s: SmallOrder is %v, expected %v", v.Name, v.SmallOrder, isSmall)
+		}
+		if v.SmallOrder && v.Canonical {
+			smallOrder++
+		}
+		if !v.SmallOrder && v.TorsionOrder > 1 {
+			mixedOrder++
+		}
+	}
+
+	if smallOrder != 8 {
+		t.Errorf("got %d canonical small order encodings, expected 8", smallOrder)
+	}
+	if mixedOrder < 7 {
+		t.Errorf("got %d mixed order encodings, expected at least 7", mixedOrder)
+	}
+	// ZIP-215 lists 26 non-canonical encodings that decode to valid points.
+	if nonCanonical != 26 {
+		t.Errorf("got %d non-canonical encodings, expected 26", nonCanonical)
+	}
+}
+
+func TestSmallOrderPoints(t *testing.T) {
+	points := SmallOrderPoints()
+	if len(points) != 8 {
+		t.Fatalf("got %d points, expected 8", len(points))
+	}
+	wantOrders := []int{1, 2, 4, 4, 8, 8, 8, 8}
+	for i, p := range points {
+		checkOnCurve(t, p)
+		for j := 0; j < i; j++ {
+			if p.Equal(points[j]) == 1 {
+				t.Errorf("points %d and %d are equal", i, j)
+			}
+		}
+		if order := torsionOrder(p); order != wantOrders[i] {
+			t.Errorf("point %d has order %d, expected %d", i, order, wantOrders[i])
+		}
+		if new(Point).MultByCofactor(p).Equal(I) != 1 {
+			t.Errorf("point %d is not of small order", i)
+		}
+	}
+
+	// The returned points are fresh copies.
+	points[0].Set(B)
+	if SmallOrderPoints()[0].Equal(I) != 1 {
+		t.Error("modifying a returned point affected later calls")
+	}
+}
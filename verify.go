@@ -0,0 +1,62 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import "bytes"
+
+// A Policy selects the rules used by VerifyEquation to check the Ed25519
+// verification equation.
+type Policy int
+
+const (
+	// PolicyCofactorless checks that the encoding of [S]B - [k]A is equal to
+	// R, matching crypto/ed25519 and RFC 8032, Section 5.1.7 without the
+	// optional multiplication by the cofactor. R must be canonical.
+	PolicyCofactorless Policy = iota
+
+	// PolicyCofactored checks that [8]([S]B - [k]A - R) is the identity,
+	// where R is decoded accepting non-canonical encodings, as specified by
+	// ZIP-215. The results are consistent across batch and single
+	// verification.
+	PolicyCofactored
+)
+
+// VerifyEquation reports whether R, S, k, and A satisfy the Ed25519
+// verification equation [S]B = R + [k]A, where B is the canonical generator,
+// according to policy. R is the 32-byte encoding of the signature's first
+// half, S its second half, k the challenge scalar SHA-512(R || A || M) mod l,
+// and A the public key.
+//
+// VerifyEquation returns false as soon as R fails to decode, if the policy
+// requires decoding it. It does not check that S is canonical, which is the
+// responsibility of the caller, for example with Scalar.SetCanonicalBytes.
+//
+// Execution time depends on the inputs, which must be public.
+func VerifyEquation(R []byte, S *Scalar, k *Scalar, A *Point, policy Policy) bool {
+	checkInitialized(A)
+	if len(R) != 32 {
+		return false
+	}
+
+	switch policy {
+	case PolicyCofactored:
+		var RR Point
+		if _, err := RR.SetBytes(R); err != nil {
+			return false
+		}
+		// The cofactored equation only needs to be checked, not computed, so
+		// it can use the half-size scalars of varTimeCofactoredCheck.
+		return varTimeCofactoredCheck(S, k, &RR, A)
+	case PolicyCofactorless:
+		// [S]B - [k]A = [-k]A + [S]B
+		minusK := new(Scalar).Negate(k)
+		var check Point
+		check.VarTimeDoubleScalarBaseMult(minusK, A, S)
+		var buf [32]byte
+		return bytes.Equal(check.bytes(&buf), R)
+	default:
+		panic("edwards25519: invalid verification policy")
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"crypto/ed25519"
+	"crypto/sha512"
+	"testing"
+)
+
+// verificationInputs returns the inputs to VerifyEquation for sig.
+func verificationInputs(t testing.TB, pub ed25519.PublicKey, msg, sig []byte) (R []byte, S, k *Scalar, A *Point) {
+	A, err := new(Point).SetBytes(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	S, err = new(Scalar).SetCanonicalBytes(sig[32:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := sha512.New()
+	h.Write(sig[:32])
+	h.Write(pub)
+	h.Write(msg)
+	k, err = new(Scalar).SetUniformBytes(h.Sum(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig[:32], S, k, A
+}
+
+func TestVerifyEquation(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	priv := ed25519.NewKeyFromSeed(seed)
+	pub := priv.Public().(ed25519.PublicKey)
+	msg := []byte("test message")
+	sig := ed25519.Sign(priv, msg)
+
+	R, S, k, A := verificationInputs(t, pub, msg, sig)
+	for _, policy := range []Policy{PolicyCofactorless, PolicyCofactored} {
+		if !VerifyEquation(R, S, k, A, policy) {
+			t.Errorf("policy %d: valid signature rejected", policy)
+		}
+		if VerifyEquation(R, S, scOne, A, policy) {
+			t.Errorf("policy %d: wrong challenge accepted", policy)
+		}
+		if VerifyEquation(R, scOne, k, A, policy) {
+			t.Errorf("policy %d: wrong S accepted", policy)
+		}
+		if VerifyEquation(R[:31], S, k, A, policy) {
+			t.Errorf("policy %d: short R accepted", policy)
+		}
+	}
+
+	// Adding a small order point to R is only accepted by the cofactored
+	// policy, which ignores the torsion component of the equation.
+	RR, _ := new(Point).SetBytes(R)
+	RR.Add(RR, SmallOrderPoints()[4])
+	mixedR := RR.Bytes()
+	if VerifyEquation(mixedR, S, k, A, PolicyCofactorless) {
+		t.Error("cofactorless policy accepted R with a torsion component")
+	}
+	if !VerifyEquation(mixedR, S, k, A, PolicyCofactored) {
+		t.Error("cofactored policy rejected R with a torsion component")
+	}
+
+	// An invalid R encoding is rejected by both policies.
+	invalid := decodeHex("0200000000000000000000000000000000000000000000000000000000000000")
+	for _, policy := range []Policy{PolicyCofactorless, PolicyCofactored} {
+		if VerifyEquation(invalid, S, k, A, policy) {
+			t.Errorf("policy %d: invalid R accepted", policy)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("unknown policy did not panic")
+		}
+	}()
+	VerifyEquation(R, S, k, A, Policy(42))
+}
+
+func BenchmarkVerifyEquation(b *testing.B) {
+	priv := ed25519.NewKeyFromSeed(make([]byte, ed25519.SeedSize))
+	msg := []byte("test message")
+	R, S, k, A := verificationInputs(b, priv.Public().(ed25519.PublicKey), msg, ed25519.Sign(priv, msg))
+	for _, policy := range []Policy{PolicyCofactorless, PolicyCofactored} {
+		b.Run([]string{"Cofactorless", "Cofactored"}[policy], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				VerifyEquation(R, S, k, A, policy)
+			}
+		})
+	}
+}
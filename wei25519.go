@@ -0,0 +1,144 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"errors"
+
+	"filippo.io/edwards25519/field"
+)
+
+// This file implements the birational map between edwards25519 and Wei25519,
+// the short Weierstrass curve
+//
+//	y² = x³ + a * x + b
+//
+// specified in Appendix E of draft-ietf-lwig-curve-representations-23. The map
+// goes through the Montgomery form of Curve25519 as
+//
+//	(u, v) = ((1 + y) / (1 - y), sqrt(-486664) * u / x)
+//	(X, Y) = (u + A / 3, v)
+//
+// where A = 486662, and the root of -486664 is chosen to match RFC 7748, so
+// that the canonical generator maps to the Wei25519 generator G of the draft.
+//
+// The identity maps to the Wei25519 point at infinity, which has no affine
+// coordinates. Every other point has a unique affine representation.
+
+// weiA and weiB are the constants in the Wei25519 curve equation.
+var weiA, _ = new(field.Element).SetBytes([]byte{
+	0x44, 0xa1, 0x14, 0x49, 0x98, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0x2a})
+var weiB, _ = new(field.Element).SetBytes([]byte{
+	0x64, 0xc8, 0x10, 0x77, 0x9c, 0x5e, 0x0b, 0x26,
+	0xb4, 0x97, 0xd0, 0x5e, 0x42, 0x7b, 0x09, 0xed,
+	0x25, 0xb4, 0x97, 0xd0, 0x5e, 0x42, 0x7b, 0x09,
+	0xed, 0x25, 0xb4, 0x97, 0xd0, 0x5e, 0x42, 0x7b})
+
+// weiDelta is A / 3, the offset between Montgomery u and Wei25519 X.
+var weiDelta, _ = new(field.Element).SetBytes([]byte{
+	0x51, 0x24, 0xad, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa,
+	0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0xaa, 0x2a})
+
+// sqrtMinus486664 is the square root of -486664 used by RFC 7748.
+var sqrtMinus486664, _ = new(field.Element).SetBytes([]byte{
+	0xe7, 0x81, 0xba, 0x00, 0x55, 0xfb, 0x91, 0x33,
+	0x7d, 0xe5, 0x82, 0xb4, 0x2e, 0x2c, 0x5e, 0x3a,
+	0x81, 0xb0, 0x03, 0xfc, 0x23, 0xf7, 0x84, 0x2d,
+	0x44, 0xf9, 0x5f, 0x9f, 0x0b, 0x12, 0xd9, 0x70})
+
+// Wei25519Coordinates returns the affine coordinates (X, Y) of the Wei25519
+// point corresponding to v.
+//
+// If v is the identity, which corresponds to the point at infinity,
+// Wei25519Coordinates returns nil, nil, and an error. Otherwise, it runs in
+// constant time.
+func (v *Point) Wei25519Coordinates() (X, Y *field.Element, err error) {
+	// This function is outlined to make the allocations inline in the caller
+	// rather than happen on the heap.
+	var e [2]field.Element
+	return v.wei25519Coordinates(&e)
+}
+
+func (v *Point) wei25519Coordinates(e *[2]field.Element) (X, Y *field.Element, err error) {
+	checkInitialized(v)
+	if v.Equal(identity) == 1 {
+		return nil, nil, errors.New("edwards25519: identity has no Wei25519 affine coordinates")
+	}
+
+	// With x = X/Z and y = Y/Z, and a single inversion of w = (Z - Y) * X,
+	//
+	//    u = (Z + Y) / (Z - Y) = (Z + Y) * X / w
+	//    v = c * u / x = c * (Z + Y) * Z / w
+	//
+	// The only non-identity point with X = 0 is (0, -1), for which Z + Y = 0
+	// and the inversion of zero returns zero, producing u = v = 0 as needed.
+	var zPlusY, w field.Element
+	zPlusY.Add(&v.z, &v.y)
+	w.Subtract(&v.z, &v.y)
+	w.Multiply(&w, &v.x)
+	w.Invert(&w)
+	w.Multiply(&w, &zPlusY)
+
+	X, Y = &e[0], &e[1]
+	X.Multiply(&w, &v.x)
+	X.Add(X, weiDelta)
+	Y.Multiply(&w, &v.z)
+	Y.Multiply(Y, sqrtMinus486664)
+	return X, Y, nil
+}
+
+// SetWei25519Coordinates sets v to the point corresponding to the Wei25519
+// point with affine coordinates (X, Y), and returns v. The point at infinity
+// has no affine coordinates, and corresponds to NewIdentityPoint.
+//
+// If (X, Y) is not on the Wei25519 curve, SetWei25519Coordinates returns nil
+// and an error, and the receiver is unchanged. The computation is done in
+// constant time, except for which error is returned.
+func (v *Point) SetWei25519Coordinates(X, Y *field.Element) (*Point, error) {
+	// Y² = X³ + a * X + b
+	var lhs, rhs field.Element
+	lhs.Square(Y)
+	rhs.Square(X)
+	rhs.Add(&rhs, weiA)
+	rhs.Multiply(&rhs, X)
+	rhs.Add(&rhs, weiB)
+	if lhs.Equal(&rhs) != 1 {
+		return nil, errors.New("edwards25519: invalid Wei25519 coordinates")
+	}
+
+	// With u = X - A / 3, and a single inversion of w = Y * (u + 1),
+	//
+	//    x = c * u / v = c * u * (u + 1) / w
+	//    y = (u - 1) / (u + 1) = (u - 1) * Y / w
+	//
+	// Curve25519 has no point with u = -1, and the only point with Y = 0 is
+	// (0, 0), which maps to (0, -1) and needs to be handled separately, since
+	// the inversion of zero returns zero.
+	var u, uPlusOne, w, x, y field.Element
+	u.Subtract(X, weiDelta)
+	uPlusOne.Add(&u, feOne)
+	w.Multiply(Y, &uPlusOne)
+	w.Invert(&w)
+
+	x.Multiply(&u, &uPlusOne)
+	x.Multiply(&x, sqrtMinus486664)
+	x.Multiply(&x, &w)
+	y.Subtract(&u, feOne)
+	y.Multiply(&y, Y)
+	y.Multiply(&y, &w)
+	var zero field.Element
+	y.Select(feMinusOne, &y, u.Equal(&zero))
+
+	v.x.Set(&x)
+	v.y.Set(&y)
+	v.z.One()
+	v.t.Multiply(&x, &y)
+	return v, nil
+}
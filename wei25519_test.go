@@ -0,0 +1,80 @@
+// Copyright (c) 2026 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package edwards25519
+
+import (
+	"testing"
+	"testing/quick"
+
+	"filippo.io/edwards25519/field"
+)
+
+func TestWei25519Generator(t *testing.T) {
+	// From draft-ietf-lwig-curve-representations-23, Appendix E.3.
+	wantX := swapEndianness(decodeHex("2aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaad245a"))
+	wantY := swapEndianness(decodeHex("20ae19a1b8a086b4e01edd2c7748d14c923d4d7e6d7c61b229e9c5a27eced3d9"))
+
+	X, Y, err := B.Wei25519Coordinates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(X.Bytes()) != string(wantX) || string(Y.Bytes()) != string(wantY) {
+		t.Errorf("got (%x, %x), want (%x, %x)", X.Bytes(), Y.Bytes(), wantX, wantY)
+	}
+
+	p, err := new(Point).SetWei25519Coordinates(X, Y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Equal(B) != 1 {
+		t.Error("generator did not round-trip")
+	}
+}
+
+func TestWei25519RoundTrip(t *testing.T) {
+	f := func(x Scalar) bool {
+		p := new(Point).ScalarBaseMult(&x)
+		p.Add(p, SmallOrderPoints()[int(x.LowBits(3))])
+		if p.Equal(I) == 1 {
+			return true
+		}
+		X, Y, err := p.Wei25519Coordinates()
+		if err != nil {
+			return false
+		}
+		q, err := new(Point).SetWei25519Coordinates(X, Y)
+		if err != nil {
+			return false
+		}
+		checkOnCurve(t, q)
+		return q.Equal(p) == 1
+	}
+	if err := quick.Check(f, quickCheckConfig(1024)); err != nil {
+		t.Error(err)
+	}
+
+	// The point of order two maps to (A / 3, 0), and back.
+	order2 := SmallOrderPoints()[1]
+	X, Y, err := order2.Wei25519Coordinates()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if X.Equal(weiDelta) != 1 || Y.Equal(new(field.Element).Zero()) != 1 {
+		t.Errorf("order two point mapped to (%x, %x)", X.Bytes(), Y.Bytes())
+	}
+	if p, err := new(Point).SetWei25519Coordinates(X, Y); err != nil || p.Equal(order2) != 1 {
+		t.Errorf("order two point did not round-trip: %v", err)
+	}
+
+	if _, _, err := I.Wei25519Coordinates(); err == nil {
+		t.Error("expected error for the identity")
+	}
+	X, Y, _ = B.Wei25519Coordinates()
+	Y.Add(Y, feOne)
+	p := new(Point).Set(B)
+	if out, err := p.SetWei25519Coordinates(X, Y); err == nil || out != nil || p.Equal(B) != 1 {
+		t.Error("expected error for point not on the curve")
+	}
+}